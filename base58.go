@@ -0,0 +1,180 @@
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// errors
+var (
+	// ErrInvalidBase58 is the cause of the *Base58Error returned for
+	// strings with characters outside of the base58 alphabet.
+	ErrInvalidBase58 = errors.New("invalid base58 character")
+	// ErrBase58Checksum is returned by DecodeBase58Check for strings
+	// whose checksum does not match, or which are too short to have
+	// one.
+	ErrBase58Checksum = errors.New("invalid base58check checksum")
+)
+
+// Base58Error reports an invalid character in a base58 string. It
+// unwraps to ErrInvalidBase58.
+type Base58Error struct {
+	// Char is the invalid character.
+	Char byte
+	// Offset is its byte offset in the string.
+	Offset int
+}
+
+func (e *Base58Error) Error() string {
+	return fmt.Sprintf("%s %q at offset %d", ErrInvalidBase58, e.Char, e.Offset)
+}
+
+// Unwrap returns ErrInvalidBase58.
+func (e *Base58Error) Unwrap() error {
+	return ErrInvalidBase58
+}
+
+// base58Alphabet is the alphabet of Bitcoin.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// bigBase58Alphabet are the digits math/big uses in base 58.
+const bigBase58Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUV"
+
+var base58Digits = func() (d [256]int8) {
+	for i := range d {
+		d[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		d[base58Alphabet[i]] = int8(i)
+	}
+	return d
+}()
+
+// fromBigBase58 translates the digits of math/big to base58Alphabet.
+var fromBigBase58 = func() (t [256]byte) {
+	for i := 0; i < len(bigBase58Alphabet); i++ {
+		t[bigBase58Alphabet[i]] = base58Alphabet[i]
+	}
+	return t
+}()
+
+// EncodeBase58 encodes b in base58 with the alphabet of Bitcoin. Each
+// leading zero byte is encoded as a leading '1'.
+func EncodeBase58(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	// math/big converts large numbers by divide and conquer, in
+	// subquadratic time
+	var digits []byte
+	if zeros < len(b) {
+		digits = []byte(new(big.Int).SetBytes(b[zeros:]).Text(58))
+	}
+
+	s := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		s[i] = '1'
+	}
+	for i, d := range digits {
+		s[zeros+i] = fromBigBase58[d]
+	}
+	return string(s)
+}
+
+// DecodeBase58 decodes a base58 string with the alphabet of Bitcoin.
+// It returns a *Base58Error for the first character outside of the
+// alphabet, before doing any arithmetic.
+func DecodeBase58(s string) ([]byte, error) {
+	digits := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		d := base58Digits[s[i]]
+		if d < 0 {
+			return nil, &Base58Error{Char: s[i], Offset: i}
+		}
+		digits[i] = byte(d)
+	}
+
+	zeros := 0
+	for zeros < len(digits) && digits[zeros] == 0 {
+		zeros++
+	}
+
+	b := make([]byte, zeros)
+	if zeros < len(digits) {
+		pows := make(map[int]*big.Int)
+		b = append(b, base58Value(digits[zeros:], pows).Bytes()...)
+	}
+	return b, nil
+}
+
+// base58Chunk is the number of base58 digits which fit in a uint64.
+const base58Chunk = 10
+
+// base58Value returns the number the digits stand for. Splitting them
+// in halves lets math/big use Karatsuba multiplication, so long inputs
+// are decoded in subquadratic time.
+func base58Value(digits []byte, pows map[int]*big.Int) *big.Int {
+	if len(digits) <= base58Chunk {
+		var v uint64
+		for _, d := range digits {
+			v = v*58 + uint64(d)
+		}
+		return new(big.Int).SetUint64(v)
+	}
+
+	mid := len(digits) / 2
+	hi := base58Value(digits[:mid], pows)
+	lo := base58Value(digits[mid:], pows)
+	hi.Mul(hi, base58Pow(len(digits)-mid, pows))
+	return hi.Add(hi, lo)
+}
+
+// base58Pow returns 58^n, caching the powers already computed.
+func base58Pow(n int, pows map[int]*big.Int) *big.Int {
+	if p, ok := pows[n]; ok {
+		return p
+	}
+
+	p := big.NewInt(58)
+	p.Exp(p, big.NewInt(int64(n)), nil)
+	pows[n] = p
+	return p
+}
+
+// EncodeBase58Check encodes payload in base58, followed by a checksum:
+// the first 4 bytes of its double sha2-256. With a version byte
+// at the start of payload, this is the encoding of Bitcoin addresses.
+func EncodeBase58Check(payload []byte) string {
+	b := make([]byte, len(payload), len(payload)+4)
+	copy(b, payload)
+	return EncodeBase58(append(b, base58Checksum(payload)...))
+}
+
+// DecodeBase58Check decodes a base58 string whose last 4 bytes are the
+// checksum of the others, and returns them. It returns
+// ErrBase58Checksum if the checksum does not match.
+func DecodeBase58Check(s string) ([]byte, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 4 {
+		return nil, ErrBase58Checksum
+	}
+
+	payload, sum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(sum, base58Checksum(payload)) {
+		return nil, ErrBase58Checksum
+	}
+	return payload, nil
+}
+
+func base58Checksum(payload []byte) []byte {
+	// DBL_SHA2_256 is always registered, with a 32 byte digest
+	m, _ := Sum(payload, DBL_SHA2_256, 4)
+	return m[2:]
+}
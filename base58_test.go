@@ -0,0 +1,137 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// The test vectors of Bitcoin Core.
+var base58TestCases = []struct {
+	hex string
+	enc string
+}{
+	{"", ""},
+	{"61", "2g"},
+	{"626262", "a3gV"},
+	{"636363", "aPEr"},
+	{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+	{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	{"516b6fcd0f", "ABnLTmg"},
+	{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+	{"572e4794", "3EFU7m"},
+	{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+	{"10c8511e", "Rt5zm"},
+	{"00000000000000000000", "1111111111"},
+}
+
+func TestBase58(t *testing.T) {
+	for _, tc := range base58TestCases {
+		b, _ := hex.DecodeString(tc.hex)
+		if s := EncodeBase58(b); s != tc.enc {
+			t.Errorf("%s: got %s, expected %s", tc.hex, s, tc.enc)
+		}
+
+		b2, err := DecodeBase58(tc.enc)
+		if err != nil {
+			t.Errorf("%s: %s", tc.enc, err)
+			continue
+		}
+		if !bytes.Equal(b, b2) {
+			t.Errorf("%s: decoded %x, expected %s", tc.enc, b2, tc.hex)
+		}
+	}
+}
+
+func TestBase58RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 9, 10, 33, 100, 1000, 20000} {
+		b := make([]byte, n)
+		r.Read(b)
+		for zeros := 0; zeros < 3 && zeros < n; zeros++ {
+			b[zeros] = 0
+
+			b2, err := DecodeBase58(EncodeBase58(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b, b2) {
+				t.Errorf("%d bytes with %d leading zeros do not round trip", n, zeros+1)
+			}
+		}
+	}
+}
+
+func TestBase58Errors(t *testing.T) {
+	cases := []struct {
+		s      string
+		char   byte
+		offset int
+	}{
+		{"0", '0', 0},
+		{"Qm0", '0', 2},
+		{"abcO", 'O', 3},
+		{"1I", 'I', 1},
+		{"l", 'l', 0},
+		{"2g ", ' ', 2},
+		{"2g\xc3\xa9", 0xc3, 2},
+	}
+
+	for _, tc := range cases {
+		_, err := DecodeBase58(tc.s)
+
+		var e *Base58Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrInvalidBase58) {
+			t.Errorf("%q: expected a *Base58Error, got %v", tc.s, err)
+			continue
+		}
+		if e.Char != tc.char || e.Offset != tc.offset {
+			t.Errorf("%q: got %q at offset %d", tc.s, e.Char, e.Offset)
+		}
+	}
+
+	for _, s := range []string{"", "Qm0"} {
+		if _, err := FromB58String(s); err != ErrInvalidMultihash {
+			t.Errorf("%q: expected ErrInvalidMultihash, got: %v", s, err)
+		}
+	}
+	if _, err := FromB58String("2g"); !errors.Is(err, ErrTooShort) {
+		t.Error("expected ErrTooShort, got: ", err)
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	// the address of the genesis block of Bitcoin
+	addr := "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	payload, _ := hex.DecodeString("0062e907b15cbf27d5425399ebf6f0fb50ebb88f18")
+
+	if s := EncodeBase58Check(payload); s != addr {
+		t.Errorf("got %s, expected %s", s, addr)
+	}
+
+	b, err := DecodeBase58Check(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, payload) {
+		t.Errorf("decoded %x, expected %x", b, payload)
+	}
+
+	for _, s := range []string{
+		"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb",
+		"1B1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+		"",
+		"2g",
+	} {
+		if _, err := DecodeBase58Check(s); err != ErrBase58Checksum {
+			t.Errorf("%q: expected ErrBase58Checksum, got: %v", s, err)
+		}
+	}
+
+	if _, err := DecodeBase58Check("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfN0"); !errors.Is(err, ErrInvalidBase58) {
+		t.Error("expected ErrInvalidBase58, got: ", err)
+	}
+}
@@ -0,0 +1,297 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// This is an implementation of BLAKE2b and BLAKE2s (RFC 7693) which
+// honours the digest length of the parameter block, so every code of
+// the blake2 multihash ranges computes a genuine digest of its size
+// rather than a truncation of a longer one.
+
+const (
+	blake2bBlockSize = 128
+	blake2sBlockSize = 64
+)
+
+var blake2bIV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+var blake2sIV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var blake2Sigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// blake2b is a hash.Hash computing BLAKE2b digests of 1 to 64 bytes.
+type blake2b struct {
+	size int
+	iv   [8]uint64
+	h    [8]uint64
+	t    [2]uint64
+	buf  [blake2bBlockSize]byte
+	nbuf int
+
+	key    [blake2bBlockSize]byte
+	keyLen int
+}
+
+// newBlake2b returns a BLAKE2b hash with a digest of size bytes.
+// The key, salt and personalization are optional and must not be
+// longer than 64, 16 and 16 bytes respectively.
+func newBlake2b(size int, key, salt, personal []byte) hash.Hash {
+	var p [64]byte
+	p[0] = byte(size)
+	p[1] = byte(len(key))
+	p[2] = 1 // fanout
+	p[3] = 1 // depth
+	copy(p[32:], salt)
+	copy(p[48:], personal)
+
+	d := &blake2b{size: size}
+	d.keyLen = copy(d.key[:], key)
+	for i := range d.iv {
+		d.iv[i] = blake2bIV[i] ^ binary.LittleEndian.Uint64(p[i*8:])
+	}
+	d.Reset()
+	return d
+}
+
+func (d *blake2b) Reset() {
+	d.h = d.iv
+	d.t = [2]uint64{}
+	d.nbuf = 0
+	if d.keyLen > 0 {
+		// a keyed hash starts with the key padded to a full block
+		d.buf = d.key
+		d.nbuf = blake2bBlockSize
+	}
+}
+
+func (d *blake2b) Size() int      { return d.size }
+func (d *blake2b) BlockSize() int { return blake2bBlockSize }
+
+func (d *blake2b) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// the last block is compressed with the final flag,
+		// so a full buffer is only flushed once more data arrives.
+		if d.nbuf == blake2bBlockSize {
+			d.compress(false)
+			d.nbuf = 0
+		}
+		c := copy(d.buf[d.nbuf:], p)
+		d.nbuf += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (d *blake2b) Sum(in []byte) []byte {
+	dd := *d
+	for i := dd.nbuf; i < blake2bBlockSize; i++ {
+		dd.buf[i] = 0
+	}
+	dd.compress(true)
+
+	var out [64]byte
+	for i, v := range dd.h {
+		binary.LittleEndian.PutUint64(out[i*8:], v)
+	}
+	return append(in, out[:d.size]...)
+}
+
+func (d *blake2b) compress(last bool) {
+	d.t[0] += uint64(d.nbuf)
+	if d.t[0] < uint64(d.nbuf) {
+		d.t[1]++
+	}
+
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(d.buf[i*8:])
+	}
+
+	var v [16]uint64
+	copy(v[:8], d.h[:])
+	copy(v[8:], blake2bIV[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if last {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, e int, x, y uint64) {
+		v[a] += v[b] + x
+		v[e] = rotr64(v[e]^v[a], 32)
+		v[c] += v[e]
+		v[b] = rotr64(v[b]^v[c], 24)
+		v[a] += v[b] + y
+		v[e] = rotr64(v[e]^v[a], 16)
+		v[c] += v[e]
+		v[b] = rotr64(v[b]^v[c], 63)
+	}
+
+	for r := 0; r < 12; r++ {
+		s := &blake2Sigma[r%10]
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+// blake2s is a hash.Hash computing BLAKE2s digests of 1 to 32 bytes.
+type blake2s struct {
+	size int
+	iv   [8]uint32
+	h    [8]uint32
+	t    [2]uint32
+	buf  [blake2sBlockSize]byte
+	nbuf int
+
+	key    [blake2sBlockSize]byte
+	keyLen int
+}
+
+// newBlake2s returns a BLAKE2s hash with a digest of size bytes.
+// The key, salt and personalization are optional and must not be
+// longer than 32, 8 and 8 bytes respectively.
+func newBlake2s(size int, key, salt, personal []byte) hash.Hash {
+	var p [32]byte
+	p[0] = byte(size)
+	p[1] = byte(len(key))
+	p[2] = 1 // fanout
+	p[3] = 1 // depth
+	copy(p[16:], salt)
+	copy(p[24:], personal)
+
+	d := &blake2s{size: size}
+	d.keyLen = copy(d.key[:], key)
+	for i := range d.iv {
+		d.iv[i] = blake2sIV[i] ^ binary.LittleEndian.Uint32(p[i*4:])
+	}
+	d.Reset()
+	return d
+}
+
+func (d *blake2s) Reset() {
+	d.h = d.iv
+	d.t = [2]uint32{}
+	d.nbuf = 0
+	if d.keyLen > 0 {
+		// a keyed hash starts with the key padded to a full block
+		d.buf = d.key
+		d.nbuf = blake2sBlockSize
+	}
+}
+
+func (d *blake2s) Size() int      { return d.size }
+func (d *blake2s) BlockSize() int { return blake2sBlockSize }
+
+func (d *blake2s) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if d.nbuf == blake2sBlockSize {
+			d.compress(false)
+			d.nbuf = 0
+		}
+		c := copy(d.buf[d.nbuf:], p)
+		d.nbuf += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (d *blake2s) Sum(in []byte) []byte {
+	dd := *d
+	for i := dd.nbuf; i < blake2sBlockSize; i++ {
+		dd.buf[i] = 0
+	}
+	dd.compress(true)
+
+	var out [32]byte
+	for i, v := range dd.h {
+		binary.LittleEndian.PutUint32(out[i*4:], v)
+	}
+	return append(in, out[:d.size]...)
+}
+
+func (d *blake2s) compress(last bool) {
+	d.t[0] += uint32(d.nbuf)
+	if d.t[0] < uint32(d.nbuf) {
+		d.t[1]++
+	}
+
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(d.buf[i*4:])
+	}
+
+	var v [16]uint32
+	copy(v[:8], d.h[:])
+	copy(v[8:], blake2sIV[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if last {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, e int, x, y uint32) {
+		v[a] += v[b] + x
+		v[e] = rotr32(v[e]^v[a], 16)
+		v[c] += v[e]
+		v[b] = rotr32(v[b]^v[c], 12)
+		v[a] += v[b] + y
+		v[e] = rotr32(v[e]^v[a], 8)
+		v[c] += v[e]
+		v[b] = rotr32(v[b]^v[c], 7)
+	}
+
+	for r := 0; r < 10; r++ {
+		s := &blake2Sigma[r]
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+func rotr64(x uint64, n uint) uint64 {
+	return x>>n | x<<(64-n)
+}
+
+func rotr32(x uint32, n uint) uint32 {
+	return x>>n | x<<(32-n)
+}
@@ -0,0 +1,56 @@
+package multihash
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestBlake2Vectors(t *testing.T) {
+	cases := []struct {
+		blake2s bool
+		size    int
+		input   []byte
+		hex     string
+	}{
+		// RFC 7693, appendix A and B
+		{false, 64, []byte("abc"), "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{true, 32, []byte("abc"), "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+
+		// empty input and exactly one block
+		{false, 64, nil, "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+		{true, 32, nil, "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+		{false, 64, make([]byte, 128), "865939e120e6805438478841afb739ae4250cf372653078a065cdcfffca4caf798e6d462b65d658fc165782640eded70963449ae1500fb0f24981d7727e22c41"},
+		{true, 32, make([]byte, 64), "ae09db7cd54f42b490ef09b6bc541af688e4959bb8c53f359a6f56e38ab454a3"},
+
+		// several blocks, parameterised digest sizes
+		{false, 64, patternInput(1000), "c11e1c0340bd7e5a1b275f1230c962fad215ecb1391486e74e31b960a2f2996381a5fad092da06841d5f26e38f6ecfeaf441acbcd1c2de61aef121e7927175f5"},
+		{false, 20, patternInput(1000), "fc9a2426db78846a07219bc181a52bae9a62eacc"},
+		{true, 32, patternInput(1000), "1c067a5e746fb0f6734efac9a8cdb0e11061f0077f255184365c690115392501"},
+		{true, 16, patternInput(1000), "f308bf57110a2e5f3c81a0ef22925035"},
+	}
+
+	for _, tc := range cases {
+		var code uint64
+		if tc.blake2s {
+			code = BLAKE2S_MIN + uint64(tc.size) - 1
+		} else {
+			code = BLAKE2B_MIN + uint64(tc.size) - 1
+		}
+
+		m, err := Sum(tc.input, code, -1)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		dm, err := Decode(m)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if hex.EncodeToString(dm.Digest) != tc.hex {
+			t.Error(dm.Name, "wrong digest", hex.EncodeToString(dm.Digest))
+		}
+	}
+}
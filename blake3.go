@@ -0,0 +1,398 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"hash"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// This is an implementation of BLAKE3, with its keyed and key
+// derivation modes, and a parallel mode which hashes the subtrees of
+// large inputs on all CPUs.
+
+const (
+	blake3BlockLen = 64
+	blake3ChunkLen = 1024
+
+	blake3ChunkStart        = 1 << 0
+	blake3ChunkEnd          = 1 << 1
+	blake3Parent            = 1 << 2
+	blake3Root              = 1 << 3
+	blake3KeyedHash         = 1 << 4
+	blake3DeriveKeyContext  = 1 << 5
+	blake3DeriveKeyMaterial = 1 << 6
+)
+
+var blake3MsgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+// blake3Compress is the BLAKE3 compression function.
+func blake3Compress(cv *[8]uint32, m *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	v := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		blake2sIV[0], blake2sIV[1], blake2sIV[2], blake2sIV[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+
+	g := func(a, b, c, d int, x, y uint32) {
+		v[a] += v[b] + x
+		v[d] = rotr32(v[d]^v[a], 16)
+		v[c] += v[d]
+		v[b] = rotr32(v[b]^v[c], 12)
+		v[a] += v[b] + y
+		v[d] = rotr32(v[d]^v[a], 8)
+		v[c] += v[d]
+		v[b] = rotr32(v[b]^v[c], 7)
+	}
+
+	s := *m
+	for r := 0; r < 7; r++ {
+		g(0, 4, 8, 12, s[0], s[1])
+		g(1, 5, 9, 13, s[2], s[3])
+		g(2, 6, 10, 14, s[4], s[5])
+		g(3, 7, 11, 15, s[6], s[7])
+		g(0, 5, 10, 15, s[8], s[9])
+		g(1, 6, 11, 12, s[10], s[11])
+		g(2, 7, 8, 13, s[12], s[13])
+		g(3, 4, 9, 14, s[14], s[15])
+
+		var p [16]uint32
+		for i, j := range blake3MsgPermutation {
+			p[i] = s[j]
+		}
+		s = p
+	}
+
+	for i := 0; i < 8; i++ {
+		v[i] ^= v[i+8]
+		v[i+8] ^= cv[i]
+	}
+	return v
+}
+
+func blake3Words(b []byte) (m [16]uint32) {
+	var block [blake3BlockLen]byte
+	copy(block[:], b)
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(block[i*4:])
+	}
+	return m
+}
+
+// blake3Output is a compression which has not been done yet, so that
+// it can either give a chaining value or the bytes of the root.
+type blake3Output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *blake3Output) chainingValue() (cv [8]uint32) {
+	v := blake3Compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags)
+	copy(cv[:], v[:8])
+	return cv
+}
+
+func (o *blake3Output) rootBytes(n int) []byte {
+	out := make([]byte, 0, n+blake3BlockLen)
+	for counter := uint64(0); len(out) < n; counter++ {
+		v := blake3Compress(&o.cv, &o.block, counter, o.blockLen, o.flags|blake3Root)
+		var b [64]byte
+		for i, w := range v {
+			binary.LittleEndian.PutUint32(b[i*4:], w)
+		}
+		out = append(out, b[:]...)
+	}
+	return out[:n]
+}
+
+func blake3ParentOutput(left, right [8]uint32, key *[8]uint32, flags uint32) blake3Output {
+	o := blake3Output{cv: *key, blockLen: blake3BlockLen, flags: flags | blake3Parent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+// blake3Chunk holds the state of the chunk being hashed.
+type blake3Chunk struct {
+	cv      [8]uint32
+	counter uint64
+	buf     [blake3BlockLen]byte
+	nbuf    int
+	blocks  int
+	flags   uint32
+}
+
+func (c *blake3Chunk) len() int {
+	return c.blocks*blake3BlockLen + c.nbuf
+}
+
+func (c *blake3Chunk) startFlag() uint32 {
+	if c.blocks == 0 {
+		return blake3ChunkStart
+	}
+	return 0
+}
+
+func (c *blake3Chunk) write(p []byte) {
+	for len(p) > 0 {
+		if c.nbuf == blake3BlockLen {
+			m := blake3Words(c.buf[:])
+			v := blake3Compress(&c.cv, &m, c.counter, blake3BlockLen, c.flags|c.startFlag())
+			copy(c.cv[:], v[:8])
+			c.blocks++
+			c.nbuf = 0
+		}
+		n := copy(c.buf[c.nbuf:], p)
+		c.nbuf += n
+		p = p[n:]
+	}
+}
+
+func (c *blake3Chunk) output() blake3Output {
+	return blake3Output{
+		cv:       c.cv,
+		block:    blake3Words(c.buf[:c.nbuf]),
+		counter:  c.counter,
+		blockLen: uint32(c.nbuf),
+		flags:    c.flags | c.startFlag() | blake3ChunkEnd,
+	}
+}
+
+// blake3 is a hash.Hash computing BLAKE3 digests of any size.
+type blake3 struct {
+	key   [8]uint32
+	flags uint32
+	size  int
+
+	// base is the counter of the first chunk, when hashing a subtree
+	// of a larger input.
+	base uint64
+
+	chunk  blake3Chunk
+	stack  [54][8]uint32
+	nstack int
+}
+
+func newBlake3(size int) *blake3 {
+	return newBlake3WithKey(blake2sIV, 0, size)
+}
+
+func newBlake3WithKey(key [8]uint32, flags uint32, size int) *blake3 {
+	d := &blake3{key: key, flags: flags, size: size}
+	d.Reset()
+	return d
+}
+
+// newKeyedBlake3 returns a BLAKE3 hash in keyed mode. The key must
+// be 32 bytes long.
+func newKeyedBlake3(key []byte, size int) *blake3 {
+	return newBlake3WithKey(blake3Key(key), blake3KeyedHash, size)
+}
+
+// newBlake3DeriveKey returns a BLAKE3 hash in key derivation mode:
+// the data written is the key material.
+func newBlake3DeriveKey(context []byte, size int) *blake3 {
+	c := newBlake3WithKey(blake2sIV, blake3DeriveKeyContext, 32)
+	c.Write(context)
+	return newBlake3WithKey(blake3Key(c.Sum(nil)), blake3DeriveKeyMaterial, size)
+}
+
+func blake3Key(b []byte) (k [8]uint32) {
+	for i := range k {
+		k[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	return k
+}
+
+func (d *blake3) Reset() {
+	d.chunk = blake3Chunk{cv: d.key, counter: d.base, flags: d.flags}
+	d.nstack = 0
+}
+
+func (d *blake3) Size() int      { return d.size }
+func (d *blake3) BlockSize() int { return blake3BlockLen }
+
+func (d *blake3) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if d.chunk.len() == blake3ChunkLen {
+			// merge the completed subtrees, as many as there are
+			// trailing zeros in the number of chunks.
+			cv := d.chunk.output()
+			d.pushChunk(cv.chainingValue())
+			d.chunk = blake3Chunk{cv: d.key, counter: d.chunk.counter + 1, flags: d.flags}
+		}
+
+		c := blake3ChunkLen - d.chunk.len()
+		if c > len(p) {
+			c = len(p)
+		}
+		d.chunk.write(p[:c])
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (d *blake3) pushChunk(cv [8]uint32) {
+	for total := d.chunk.counter - d.base + 1; total&1 == 0; total >>= 1 {
+		d.nstack--
+		o := blake3ParentOutput(d.stack[d.nstack], cv, &d.key, d.flags)
+		cv = o.chainingValue()
+	}
+	d.stack[d.nstack] = cv
+	d.nstack++
+}
+
+// output returns the root output of the data written so far.
+func (d *blake3) output() blake3Output {
+	o := d.chunk.output()
+	for i := d.nstack - 1; i >= 0; i-- {
+		o = blake3ParentOutput(d.stack[i], o.chainingValue(), &d.key, d.flags)
+	}
+	return o
+}
+
+func (d *blake3) Sum(in []byte) []byte {
+	o := d.output()
+	return append(in, o.rootBytes(d.size)...)
+}
+
+// blake3ParallelLeaf is the size of the subtrees hashed by each
+// goroutine in parallel mode. It must be a power of two multiple of
+// the chunk length.
+const blake3ParallelLeaf = 256 << 10
+
+// blake3Parallel computes the BLAKE3 digest of size bytes of an
+// input of n bytes, hashing its subtrees in parallel. leaf is called
+// with a new blake3 hash, in which to write the data of the subtree
+// at the given offset and of the given length.
+func blake3Parallel(n int64, size int, leaf func(d *blake3, off, length int64) error) ([]byte, error) {
+	leaves := int((n + blake3ParallelLeaf - 1) / blake3ParallelLeaf)
+	if leaves <= 1 {
+		d := newBlake3(size)
+		if err := leaf(d, 0, n); err != nil {
+			return nil, err
+		}
+		return d.Sum(nil), nil
+	}
+
+	cvs := make([][8]uint32, leaves)
+	var next int64 = -1
+	var wg sync.WaitGroup
+	var once sync.Once
+	var ferr error
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > leaves {
+		workers = leaves
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= leaves {
+					return
+				}
+
+				off := int64(i) * blake3ParallelLeaf
+				length := n - off
+				if length > blake3ParallelLeaf {
+					length = blake3ParallelLeaf
+				}
+
+				d := newBlake3(size)
+				d.base = uint64(off / blake3ChunkLen)
+				d.Reset()
+				if err := leaf(d, off, length); err != nil {
+					once.Do(func() { ferr = err })
+					return
+				}
+				o := d.output()
+				cvs[i] = o.chainingValue()
+			}
+		}()
+	}
+	wg.Wait()
+	if ferr != nil {
+		return nil, ferr
+	}
+
+	// the tree above the leaves: left subtrees are the largest power
+	// of two number of leaves which leaves something on the right.
+	var node func(cvs [][8]uint32) [8]uint32
+	split := func(cvs [][8]uint32) int {
+		l := 1
+		for l*2 < len(cvs) {
+			l *= 2
+		}
+		return l
+	}
+	node = func(cvs [][8]uint32) [8]uint32 {
+		if len(cvs) == 1 {
+			return cvs[0]
+		}
+		l := split(cvs)
+		o := blake3ParentOutput(node(cvs[:l]), node(cvs[l:]), &blake2sIV, 0)
+		return o.chainingValue()
+	}
+
+	l := split(cvs)
+	o := blake3ParentOutput(node(cvs[:l]), node(cvs[l:]), &blake2sIV, 0)
+	return o.rootBytes(size), nil
+}
+
+// SumBlake3Parallel is like Sum with the BLAKE3 code, but uses all
+// CPUs to hash large inputs.
+func SumBlake3Parallel(data []byte, length int) (Multihash, error) {
+	if length < 0 {
+		length, _ = LookupDefaultLength(BLAKE3)
+	}
+	if err := checkXOFLength(BLAKE3, length); err != nil {
+		return nil, err
+	}
+
+	d, err := blake3Parallel(int64(len(data)), length, func(d *blake3, off, n int64) error {
+		d.Write(data[off : off+n])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Encode(d, BLAKE3)
+}
+
+// SumBlake3ReaderAt is like SumBlake3Parallel, for the first size
+// bytes of r. Parts of r are read concurrently.
+func SumBlake3ReaderAt(r io.ReaderAt, size int64, length int) (Multihash, error) {
+	if length < 0 {
+		length, _ = LookupDefaultLength(BLAKE3)
+	}
+	if err := checkXOFLength(BLAKE3, length); err != nil {
+		return nil, err
+	}
+
+	d, err := blake3Parallel(size, length, func(d *blake3, off, n int64) error {
+		_, err := io.CopyN(d, io.NewSectionReader(r, off, n), n)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Encode(d, BLAKE3)
+}
+
+// blake3HashFunc is the HashFunc of BLAKE3, which is an extendable
+// output function.
+func blake3HashFunc(length int) (hash.Hash, error) {
+	if err := checkXOFLength(BLAKE3, length); err != nil {
+		return nil, err
+	}
+	return newBlake3(length), nil
+}
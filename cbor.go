@@ -0,0 +1,391 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// errors
+var (
+	ErrInvalidCBOR = errors.New("invalid CBOR")
+	ErrInvalidLink = errors.New("invalid dag-cbor link")
+)
+
+// CBORLinkTag is the CBOR tag of links in dag-cbor, the IPLD encoding of
+// CBOR. A link is a byte string of a 0x00 multibase prefix and a CID.
+const CBORLinkTag = 42
+
+// cidRaw is the multicodec of raw binary data, the default content
+// type of the CIDs of links.
+const cidRaw = 0x55
+
+// CBOR major types
+const (
+	cborUint  = 0
+	cborBytes = 2
+	cborText  = 3
+	cborMap   = 5
+	cborTag   = 6
+)
+
+// cborNull is the CBOR encoding of null.
+const cborNull = 0xf6
+
+// CBOROptions configures EncodeCBOR.
+type CBOROptions struct {
+	// Link encodes the multihash as a dag-cbor link: tag 42 of the CIDv1
+	// of the multihash, instead of a plain byte string.
+	Link bool
+	// LinkCodec is the content type of the CID of links, such as 0x71
+	// for dag-cbor. Zero means raw (0x55).
+	LinkCodec uint64
+}
+
+// EncodeCBOR encodes m as a CBOR byte string, or as a dag-cbor link
+// if opts.Link is set. It returns an error if m is not a valid
+// multihash.
+func EncodeCBOR(m Multihash, opts CBOROptions) ([]byte, error) {
+	if _, err := Cast(m); err != nil {
+		return nil, err
+	}
+	if !opts.Link {
+		return appendCBORBytes(make([]byte, 0, len(m)+9), m), nil
+	}
+
+	codec := opts.LinkCodec
+	if codec == 0 {
+		codec = cidRaw
+	}
+	cid := make([]byte, 2, 2+binary.MaxVarintLen64+len(m))
+	cid[0] = 0x00 // identity multibase
+	cid[1] = 0x01 // CIDv1
+	cid = appendUvarint(cid, codec)
+	cid = append(cid, m...)
+
+	buf := appendCBORHead(make([]byte, 0, len(cid)+11), cborTag, CBORLinkTag)
+	return appendCBORBytes(buf, cid), nil
+}
+
+// DecodeCBOR decodes a multihash encoded by EncodeCBOR, either as a byte
+// string or as a dag-cbor link. Links to CIDv0 are accepted too. data
+// must hold a single CBOR item, and the multihash is checked with Cast.
+func DecodeCBOR(data []byte) (Multihash, error) {
+	d := &cborDecoder{buf: data}
+
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	link := major == cborTag
+	if link {
+		if arg != CBORLinkTag {
+			return nil, d.errorf("unexpected tag %d", arg)
+		}
+		if major, arg, err = d.head(); err != nil {
+			return nil, err
+		}
+	}
+	if major != cborBytes {
+		return nil, d.errorf("expected a byte string, got major type %d", major)
+	}
+	b, err := d.bytes(arg)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.end(); err != nil {
+		return nil, err
+	}
+
+	if link {
+		if b, err = cidMultihash(b); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := Cast(b); err != nil {
+		return nil, err
+	}
+	return append(Multihash{}, b...), nil
+}
+
+// cidMultihash returns the multihash of the CID of a dag-cbor link.
+func cidMultihash(b []byte) ([]byte, error) {
+	if len(b) == 0 || b[0] != 0x00 {
+		return nil, fmt.Errorf("%w: missing the 0x00 multibase prefix", ErrInvalidLink)
+	}
+	b = b[1:]
+
+	// a CIDv0 is a bare sha2-256 multihash
+	if len(b) == 34 && b[0] == SHA2_256 && b[1] == 32 {
+		return b, nil
+	}
+
+	version, b, err := uvarint(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLink, err)
+	}
+	if version != 1 {
+		return nil, fmt.Errorf("%w: unsupported CID version %d", ErrInvalidLink, version)
+	}
+	if _, b, err = uvarint(b); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLink, err)
+	}
+	return b, nil
+}
+
+// MarshalCBOR encodes m as a CBOR byte string, or as null if it is nil.
+// Use EncodeCBOR for dag-cbor links.
+func (m Multihash) MarshalCBOR() ([]byte, error) {
+	if m == nil {
+		return []byte{cborNull}, nil
+	}
+	return EncodeCBOR(m, CBOROptions{})
+}
+
+// UnmarshalCBOR decodes a multihash with DecodeCBOR. Like UnmarshalJSON,
+// it leaves m unchanged for null.
+func (m *Multihash) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && data[0] == cborNull {
+		return nil
+	}
+
+	m2, err := DecodeCBOR(data)
+	if err != nil {
+		return err
+	}
+	*m = m2
+	return nil
+}
+
+// MarshalCBOR encodes dm as a CBOR map with the same fields as its JSON
+// form, except that the digest is a byte string. The keys are sorted as
+// dag-cbor requires, so that the encoding is also valid dag-cbor:
+//
+//	{"code": 17, "name": "sha1", "digest": h'0beec7b5...', "length": 20}
+//
+// The name is omitted for codes unknown to this package. It returns an
+// error if the length is not that of the digest.
+func (dm DecodedMultihash) MarshalCBOR() ([]byte, error) {
+	if dm.Length != len(dm.Digest) {
+		return nil, &Error{Err: ErrInconsistentLen{}, Code: dm.Code, Length: dm.Length, Actual: len(dm.Digest), Offset: -1}
+	}
+
+	n := uint64(3)
+	if dm.Name != "" {
+		n++
+	}
+	buf := make([]byte, 0, 48+len(dm.Name)+len(dm.Digest))
+	buf = appendCBORHead(buf, cborMap, n)
+	buf = appendCBORText(buf, "code")
+	buf = appendCBORHead(buf, cborUint, dm.Code)
+	if dm.Name != "" {
+		buf = appendCBORText(buf, "name")
+		buf = appendCBORText(buf, dm.Name)
+	}
+	buf = appendCBORText(buf, "digest")
+	buf = appendCBORBytes(buf, dm.Digest)
+	buf = appendCBORText(buf, "length")
+	buf = appendCBORHead(buf, cborUint, uint64(dm.Length))
+	return buf, nil
+}
+
+// UnmarshalCBOR parses the CBOR form of MarshalCBOR, in any key order.
+// The code, length and digest are required, and other keys are not
+// allowed. Like UnmarshalJSON, it checks that the length is that of
+// the digest, and that the name, if present, is the one of the code.
+func (dm *DecodedMultihash) UnmarshalCBOR(data []byte) error {
+	d := &cborDecoder{buf: data}
+
+	major, n, err := d.head()
+	if err != nil {
+		return err
+	}
+	if major != cborMap {
+		return d.errorf("expected a map, got major type %d", major)
+	}
+	if n > 4 {
+		return d.errorf("unexpected map of %d entries", n)
+	}
+
+	var (
+		name   string
+		code   uint64
+		length uint64
+		digest []byte
+		seen   = make(map[string]bool, n)
+	)
+	for i := uint64(0); i < n; i++ {
+		key, err := d.text()
+		if err != nil {
+			return err
+		}
+		if seen[key] {
+			return d.errorf("duplicate key %q", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "code":
+			code, err = d.uint()
+		case "name":
+			name, err = d.text()
+		case "length":
+			length, err = d.uint()
+		case "digest":
+			digest, err = d.byteString()
+		default:
+			err = d.errorf("unexpected key %q", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := d.end(); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"code", "length", "digest"} {
+		if !seen[key] {
+			return fmt.Errorf("%w: missing key %q", ErrInvalidCBOR, key)
+		}
+	}
+	if length > uint64(^uint(0)>>1) {
+		// too large for an int
+		return &Error{Err: ErrInconsistentLen{}, Code: code, Length: -1, Actual: len(digest), Offset: -1}
+	}
+	return dm.set(name, code, int(length), append([]byte{}, digest...))
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	return append(buf, b[:n]...)
+}
+
+// appendCBORHead appends the shortest head of an item of the major type
+// with the argument, as both canonical CBOR and dag-cbor require.
+func appendCBORHead(buf []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(buf, major|byte(arg))
+	case arg <= 0xff:
+		return append(buf, major|24, byte(arg))
+	case arg <= 0xffff:
+		return append(buf, major|25, byte(arg>>8), byte(arg))
+	case arg <= 0xffffffff:
+		return append(buf, major|26, byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	default:
+		buf = append(buf, major|27)
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], arg)
+		return append(buf, b[:]...)
+	}
+}
+
+func appendCBORBytes(buf, b []byte) []byte {
+	return append(appendCBORHead(buf, cborBytes, uint64(len(b))), b...)
+}
+
+func appendCBORText(buf []byte, s string) []byte {
+	return append(appendCBORHead(buf, cborText, uint64(len(s))), s...)
+}
+
+// cborDecoder reads the few CBOR items used by multihashes. It rejects
+// indefinite lengths and heads which are not the shortest, so that
+// only canonical encodings are accepted.
+type cborDecoder struct {
+	buf []byte
+	off int
+}
+
+func (d *cborDecoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidCBOR, fmt.Sprintf(format, args...), d.off)
+}
+
+func (d *cborDecoder) head() (major byte, arg uint64, err error) {
+	if d.off >= len(d.buf) {
+		return 0, 0, d.errorf("unexpected end of data")
+	}
+	b := d.buf[d.off]
+	major, info := b>>5, b&0x1f
+
+	var n int
+	switch {
+	case info < 24:
+		d.off++
+		return major, uint64(info), nil
+	case info <= 27:
+		n = 1 << (info - 24)
+	case info == 31:
+		return 0, 0, d.errorf("indefinite lengths are not supported")
+	default:
+		return 0, 0, d.errorf("invalid additional information %d", info)
+	}
+	if len(d.buf)-d.off-1 < n {
+		return 0, 0, d.errorf("unexpected end of data")
+	}
+	for _, c := range d.buf[d.off+1 : d.off+1+n] {
+		arg = arg<<8 | uint64(c)
+	}
+	if least := []uint64{24, 0x100, 0x10000, 0x100000000}[info-24]; arg < least {
+		return 0, 0, d.errorf("non-canonical argument %d", arg)
+	}
+	d.off += 1 + n
+	return major, arg, nil
+}
+
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.buf)-d.off) {
+		return nil, d.errorf("unexpected end of data")
+	}
+	b := d.buf[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+func (d *cborDecoder) item(want byte) (uint64, error) {
+	major, arg, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	if major != want {
+		return 0, d.errorf("expected major type %d, got %d", want, major)
+	}
+	return arg, nil
+}
+
+func (d *cborDecoder) uint() (uint64, error) {
+	return d.item(cborUint)
+}
+
+func (d *cborDecoder) byteString() ([]byte, error) {
+	n, err := d.item(cborBytes)
+	if err != nil {
+		return nil, err
+	}
+	return d.bytes(n)
+}
+
+func (d *cborDecoder) text() (string, error) {
+	n, err := d.item(cborText)
+	if err != nil {
+		return "", err
+	}
+	b, err := d.bytes(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", d.errorf("invalid UTF-8 text")
+	}
+	return string(b), nil
+}
+
+func (d *cborDecoder) end() error {
+	if d.off != len(d.buf) {
+		return d.errorf("unexpected trailing data")
+	}
+	return nil
+}
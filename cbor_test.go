@@ -0,0 +1,212 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+const fooSHA256 = "12202c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, tc := range testCases {
+		m, err := tc.Multihash()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := m.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m2 Multihash
+		if err := m2.UnmarshalCBOR(b); err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+			continue
+		}
+		if !bytes.Equal(m, m2) {
+			t.Errorf("%s: decoded %x, expected %x", tc.name, m2, m)
+		}
+
+		for _, codec := range []uint64{0, 0x71, 0x0129} {
+			b, err := EncodeCBOR(m, CBOROptions{Link: true, LinkCodec: codec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b[0] != 0xd8 || b[1] != CBORLinkTag {
+				t.Errorf("%s: link not tagged: %x", tc.name, b)
+			}
+			m2, err := DecodeCBOR(b)
+			if err != nil {
+				t.Errorf("%s: %s", tc.name, err)
+				continue
+			}
+			if !bytes.Equal(m, m2) {
+				t.Errorf("%s: decoded link %x, expected %x", tc.name, m2, m)
+			}
+		}
+
+		dm, err := Decode(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err = dm.MarshalCBOR(); err != nil {
+			t.Fatal(err)
+		}
+		var dm2 DecodedMultihash
+		if err := dm2.UnmarshalCBOR(b); err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+			continue
+		}
+		if dm2.Code != dm.Code || dm2.Name != dm.Name || dm2.Length != dm.Length || !bytes.Equal(dm2.Digest, dm.Digest) {
+			t.Errorf("%s: decoded %+v, expected %+v", tc.name, dm2, dm)
+		}
+	}
+}
+
+func TestCBOREncoding(t *testing.T) {
+	m, _ := FromHexString(fooSHA256)
+
+	cases := []struct {
+		opts CBOROptions
+		hex  string
+	}{
+		{CBOROptions{}, "5822" + fooSHA256},
+		{CBOROptions{Link: true}, "d82a5825000155" + fooSHA256},
+		{CBOROptions{Link: true, LinkCodec: 0x71}, "d82a5825000171" + fooSHA256},
+		{CBOROptions{Link: true, LinkCodec: 0x0129}, "d82a58260001a902" + fooSHA256},
+	}
+	for _, tc := range cases {
+		b, err := EncodeCBOR(m, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(b) != tc.hex {
+			t.Errorf("%+v: got %x, expected %s", tc.opts, b, tc.hex)
+		}
+	}
+
+	// links to CIDv0
+	b, _ := hex.DecodeString("d82a582300" + fooSHA256)
+	m2, err := DecodeCBOR(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(m, m2) {
+		t.Errorf("decoded %x", m2)
+	}
+
+	if _, err := EncodeCBOR(m[:10], CBOROptions{}); !errors.Is(err, ErrInconsistentLen{}) {
+		t.Error("expected ErrInconsistentLen, got: ", err)
+	}
+}
+
+func TestCBORNull(t *testing.T) {
+	var m Multihash
+	b, err := m.MarshalCBOR()
+	if err != nil || !bytes.Equal(b, []byte{0xf6}) {
+		t.Errorf("got %x, %v", b, err)
+	}
+
+	m, _ = FromHexString(fooSHA256)
+	if err := m.UnmarshalCBOR(b); err != nil || m.HexString() != fooSHA256 {
+		t.Errorf("got %x, %v", m, err)
+	}
+}
+
+func TestDecodeCBORErrors(t *testing.T) {
+	cases := []struct {
+		hex string
+		err error
+	}{
+		{"", ErrInvalidCBOR},
+		{"5822" + fooSHA256 + "00", ErrInvalidCBOR},     // trailing data
+		{"5823" + fooSHA256, ErrInvalidCBOR},            // truncated
+		{"7822" + fooSHA256, ErrInvalidCBOR},            // text string
+		{"590022" + fooSHA256, ErrInvalidCBOR},          // non-canonical length
+		{"5f5822" + fooSHA256 + "ff", ErrInvalidCBOR},   // indefinite length
+		{"5c", ErrInvalidCBOR},                          // reserved
+		{"d82b5825000155" + fooSHA256, ErrInvalidCBOR},  // other tag
+		{"d82a5825010155" + fooSHA256, ErrInvalidLink},  // no multibase prefix
+		{"d82a5825000255" + fooSHA256, ErrInvalidLink},  // CIDv2
+		{"d82a4100", ErrInvalidLink},                    // empty CID
+		{"5821" + fooSHA256[:66], ErrInconsistentLen{}}, // short digest
+		{"d82a5823000155" + fooSHA256[:64], ErrInconsistentLen{}},
+	}
+	for _, tc := range cases {
+		b, _ := hex.DecodeString(tc.hex)
+		if _, err := DecodeCBOR(b); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.hex, tc.err, err)
+		}
+	}
+}
+
+func TestDecodedMultihashCBOR(t *testing.T) {
+	m, _ := Sum([]byte("foo"), SHA1, -1)
+	dm, _ := Decode(m)
+
+	b, err := dm.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// {"code": 17, "name": "sha1", "digest": h'...', "length": 20}
+	want := "a464636f646511646e616d65647368613166646967657374540beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33666c656e67746814"
+	if hex.EncodeToString(b) != want {
+		t.Errorf("got %x, expected %s", b, want)
+	}
+
+	// the name is optional, and the keys may be in any order
+	var dm2 DecodedMultihash
+	b, _ = hex.DecodeString("a3666c656e6774680166646967657374410b64636f646511")
+	if err := dm2.UnmarshalCBOR(b); err != nil {
+		t.Fatal(err)
+	}
+	if dm2.Name != "sha1" || dm2.Code != SHA1 || dm2.Length != 1 || !bytes.Equal(dm2.Digest, []byte{0x0b}) {
+		t.Errorf("decoded %+v", dm2)
+	}
+
+	// unknown codes have no name
+	b, err = DecodedMultihash{Code: 0x300000, Length: 1, Digest: []byte{1}}.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a364636f64651a00300000666469676573744101666c656e67746801"; hex.EncodeToString(b) != want {
+		t.Errorf("got %x, expected %s", b, want)
+	}
+
+	if _, err := (DecodedMultihash{Code: SHA1, Length: 2, Digest: []byte{1}}).MarshalCBOR(); !errors.Is(err, ErrInconsistentLen{}) {
+		t.Error("expected ErrInconsistentLen, got: ", err)
+	}
+
+	cases := []struct {
+		hex string
+		err error
+	}{
+		// length 2, digest h'0b'
+		{"a364636f646511666c656e6774680266646967657374410b", ErrInconsistentLen{}},
+		// name "md5", code 17
+		{"a464636f646511646e616d65636d6435666c656e6774680166646967657374410b", ErrUnknownCode},
+		// huge length
+		{"a364636f646511666c656e6774681bffffffffffffffff66646967657374410b", ErrInconsistentLen{}},
+		// missing digest
+		{"a264636f646511666c656e67746801", ErrInvalidCBOR},
+		// duplicate code
+		{"a364636f64651164636f646511666c656e67746800", ErrInvalidCBOR},
+		// unknown key
+		{"a464636f646511666c656e6774680166646967657374410b6178f6", ErrInvalidCBOR},
+		// code as a text string
+		{"a364636f64656131666c656e6774680166646967657374410b", ErrInvalidCBOR},
+		// an array
+		{"831101410b", ErrInvalidCBOR},
+	}
+	for _, tc := range cases {
+		b, err := hex.DecodeString(tc.hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm2.UnmarshalCBOR(b); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.hex, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package multihash
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// ErrInvalidChunkSizes is returned by NewChunker when the chunk sizes
+// of its ChunkerOptions are inconsistent.
+var ErrInvalidChunkSizes = errors.New("invalid chunk sizes: need 0 < min <= avg <= max, with avg a power of two of at least 64")
+
+// Content-defined chunking algorithms.
+const (
+	// ChunkFastCDC is FastCDC, a gear hash with normalized chunking
+	// (level 1). It is the default.
+	ChunkFastCDC = "fastcdc"
+	// ChunkRabin is a Rabin fingerprint over a sliding window of 64
+	// bytes, as in LBFS and restic.
+	ChunkRabin = "rabin"
+)
+
+// Default chunk sizes, as in the FastCDC paper.
+const (
+	DefaultChunkMinSize = 2 << 10
+	DefaultChunkAvgSize = 8 << 10
+	DefaultChunkMaxSize = 64 << 10
+)
+
+// ChunkerOptions configures a Chunker. The zero value chunks with
+// FastCDC and the default sizes.
+type ChunkerOptions struct {
+	// Algorithm is ChunkFastCDC (or empty) or ChunkRabin.
+	Algorithm string
+
+	// MinSize and MaxSize bound the size of the chunks, in bytes,
+	// except for the last one which may be shorter than MinSize.
+	// Zero means AvgSize/4 and AvgSize*8 respectively.
+	MinSize int
+	MaxSize int
+
+	// AvgSize is the size the chunks are aimed at. It must be a power
+	// of two. Zero means DefaultChunkAvgSize.
+	AvgSize int
+}
+
+// Chunk is a piece of the data read by a Chunker.
+type Chunk struct {
+	Offset    int64
+	Length    int
+	Multihash Multihash
+}
+
+// Chunker splits the data of an io.Reader into content-defined
+// chunks: their boundaries depend on the data around them, not on
+// their offset, so inserting or removing bytes only changes the
+// chunks nearby. This makes it possible to deduplicate the chunks of
+// similar files or of successive backups.
+//
+// The boundaries are stable: for given options, the same data is
+// always split at the same offsets, whatever the multihash code.
+type Chunker struct {
+	r   io.Reader
+	h   Hasher
+	cut func(data []byte) int
+
+	buf        []byte
+	start, end int
+	eof        bool
+	offset     int64
+}
+
+// NewChunker returns a Chunker reading from r, which hashes the
+// chunks with the given multihash code and length (negative for the
+// default length).
+func NewChunker(r io.Reader, code uint64, length int, opts ChunkerOptions) (*Chunker, error) {
+	if opts.AvgSize == 0 {
+		opts.AvgSize = DefaultChunkAvgSize
+	}
+	if opts.MinSize == 0 {
+		opts.MinSize = opts.AvgSize / 4
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = opts.AvgSize * 8
+	}
+	if opts.MinSize <= 0 || opts.AvgSize < 64 || opts.AvgSize&(opts.AvgSize-1) != 0 ||
+		opts.MinSize > opts.AvgSize || opts.AvgSize > opts.MaxSize {
+		return nil, ErrInvalidChunkSizes
+	}
+
+	var cut func([]byte) int
+	switch opts.Algorithm {
+	case "", ChunkFastCDC:
+		cut = newFastCDC(opts).cut
+	case ChunkRabin:
+		cut = newRabin(opts).cut
+	default:
+		return nil, errors.New("unknown chunking algorithm: " + opts.Algorithm)
+	}
+
+	h, err := NewHasher(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chunker{
+		r:   r,
+		h:   h,
+		cut: cut,
+		buf: make([]byte, 2*opts.MaxSize),
+	}, nil
+}
+
+// Next returns the next chunk, or io.EOF when all the data has been
+// read. Empty data has no chunks.
+func (c *Chunker) Next() (Chunk, error) {
+	if err := c.fill(len(c.buf) / 2); err != nil {
+		return Chunk{}, err
+	}
+	if c.start == c.end {
+		return Chunk{}, io.EOF
+	}
+
+	n := c.cut(c.buf[c.start:c.end])
+	data := c.buf[c.start : c.start+n]
+
+	c.h.Reset()
+	c.h.Write(data)
+	m, err := c.h.Multihash()
+	if err != nil {
+		return Chunk{}, err
+	}
+
+	chunk := Chunk{Offset: c.offset, Length: n, Multihash: m}
+	c.start += n
+	c.offset += int64(n)
+	return chunk, nil
+}
+
+// fill reads until the buffer holds at least n bytes, or the end of
+// the data.
+func (c *Chunker) fill(n int) error {
+	if c.end-c.start >= n || c.eof {
+		return nil
+	}
+
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+
+	for c.end < n {
+		m, err := c.r.Read(c.buf[c.end:])
+		c.end += m
+		if err == io.EOF {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fastCDC implements FastCDC, from "FastCDC: a Fast and Efficient
+// Content-Defined Chunking Approach for Data Deduplication" (Xia et
+// al., 2016).
+type fastCDC struct {
+	min, avg, max int
+	// maskS has more bits than a mask of avg, so that cut points
+	// before avg are unlikely, and maskL fewer
+	maskS, maskL uint64
+}
+
+func newFastCDC(opts ChunkerOptions) *fastCDC {
+	b := uint(bits.TrailingZeros(uint(opts.AvgSize)))
+
+	// the gear hash shifts left, so its high bits depend on the most
+	// bytes: the masks select them
+	return &fastCDC{
+		min:   opts.MinSize,
+		avg:   opts.AvgSize,
+		max:   opts.MaxSize,
+		maskS: ^uint64(0) << (64 - b - 1),
+		maskL: ^uint64(0) << (64 - b + 1),
+	}
+}
+
+// gearTable holds the random values of the gear hash, derived from
+// splitmix64 so that chunk boundaries never change.
+var gearTable = func() (t [256]uint64) {
+	var x uint64
+	for i := range t {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		t[i] = z ^ z>>31
+	}
+	return t
+}()
+
+func (f *fastCDC) cut(data []byte) int {
+	n := len(data)
+	if n <= f.min {
+		return n
+	}
+	if n > f.max {
+		n = f.max
+	}
+	normal := f.avg
+	if normal > n {
+		normal = n
+	}
+
+	var fp uint64
+	i := f.min
+	for ; i < normal; i++ {
+		fp = fp<<1 + gearTable[data[i]]
+		if fp&f.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		fp = fp<<1 + gearTable[data[i]]
+		if fp&f.maskL == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
+
+// rabinPolynomial is the irreducible polynomial of degree 53 of the
+// Rabin fingerprints. It is the one restic uses in its tests.
+const rabinPolynomial = 0x3DA3358B4DC173
+
+const (
+	rabinWindowSize = 64
+	rabinDegree     = 53
+)
+
+// rabinTables are the precomputed values to slide the window: out
+// removes the contribution of the byte leaving it, and mod reduces
+// the fingerprint after a byte has been shifted in.
+var rabinTables = func() (t struct{ out, mod [256]uint64 }) {
+	for b := 0; b < 256; b++ {
+		h := polyMod(uint64(b), rabinPolynomial)
+		for i := 0; i < rabinWindowSize-1; i++ {
+			h = polyMod(h<<8, rabinPolynomial)
+		}
+		t.out[b] = h
+
+		t.mod[b] = polyMod(uint64(b)<<rabinDegree, rabinPolynomial) | uint64(b)<<rabinDegree
+	}
+	return t
+}()
+
+// polyMod returns x modulo p, as polynomials over GF(2).
+func polyMod(x, p uint64) uint64 {
+	dp := bits.Len64(p)
+	for {
+		dx := bits.Len64(x)
+		if dx < dp {
+			return x
+		}
+		x ^= p << uint(dx-dp)
+	}
+}
+
+// rabin cuts data where the fingerprint of the last 64 bytes has as
+// many low zero bits as the average size.
+type rabin struct {
+	min, max int
+	mask     uint64
+}
+
+func newRabin(opts ChunkerOptions) *rabin {
+	return &rabin{
+		min:  opts.MinSize,
+		max:  opts.MaxSize,
+		mask: uint64(opts.AvgSize - 1),
+	}
+}
+
+func (r *rabin) cut(data []byte) int {
+	n := len(data)
+	if n <= r.min {
+		return n
+	}
+	if n > r.max {
+		n = r.max
+	}
+
+	// the window only covers the bytes before the minimum size
+	i := r.min - rabinWindowSize
+	if i < 0 {
+		i = 0
+	}
+
+	var window [rabinWindowSize]byte
+	var wpos int
+	var digest uint64
+	for ; i < n; i++ {
+		b := data[i]
+		digest ^= rabinTables.out[window[wpos]]
+		window[wpos] = b
+		wpos = (wpos + 1) % rabinWindowSize
+
+		index := digest >> (rabinDegree - 8)
+		digest = (digest<<8 | uint64(b)) ^ rabinTables.mod[index]
+
+		if i+1 >= r.min && digest&r.mask == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
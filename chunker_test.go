@@ -0,0 +1,165 @@
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+func chunkerTestData(n int) []byte {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(42)).Read(data)
+	return data
+}
+
+func readChunks(t *testing.T, r io.Reader, opts ChunkerOptions) []Chunk {
+	t.Helper()
+
+	c, err := NewChunker(r, SHA2_256, -1, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var chunks []Chunk
+	for {
+		chunk, err := c.Next()
+		if err == io.EOF {
+			return chunks
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+func TestChunker(t *testing.T) {
+	data := chunkerTestData(1 << 20)
+
+	for _, algo := range []string{ChunkFastCDC, ChunkRabin} {
+		opts := ChunkerOptions{Algorithm: algo, MinSize: 1024, AvgSize: 4096, MaxSize: 16384}
+		chunks := readChunks(t, bytes.NewReader(data), opts)
+
+		var offset int64
+		for i, c := range chunks {
+			if c.Offset != offset {
+				t.Fatalf("%s: chunk %d at offset %d, expected %d", algo, i, c.Offset, offset)
+			}
+			if c.Length > opts.MaxSize || (c.Length < opts.MinSize && i != len(chunks)-1) {
+				t.Errorf("%s: chunk %d has length %d", algo, i, c.Length)
+			}
+
+			m, _ := Sum(data[offset:offset+int64(c.Length)], SHA2_256, -1)
+			if !bytes.Equal(c.Multihash, m) {
+				t.Errorf("%s: chunk %d has the wrong multihash", algo, i)
+			}
+			offset += int64(c.Length)
+		}
+		if offset != int64(len(data)) {
+			t.Errorf("%s: chunks cover %d bytes, expected %d", algo, offset, len(data))
+		}
+
+		if avg := len(data) / len(chunks); avg < opts.AvgSize/2 || avg > opts.AvgSize*2 {
+			t.Errorf("%s: average chunk size is %d", algo, avg)
+		}
+
+		slow := readChunks(t, iotest.OneByteReader(bytes.NewReader(data)), opts)
+		if len(slow) != len(chunks) || slow[len(slow)-1].Offset != chunks[len(chunks)-1].Offset {
+			t.Errorf("%s: chunks depend on the reads", algo)
+		}
+	}
+}
+
+// TestChunkerStable checks that the boundaries never change, as that
+// would break deduplication.
+func TestChunkerStable(t *testing.T) {
+	data := chunkerTestData(1 << 16)
+
+	for algo, want := range map[string][]int{
+		ChunkFastCDC: {0, 17404, 25462, 35370, 50698, 58321},
+		ChunkRabin:   {0, 6040, 11125, 30456, 42562, 47820, 50283, 60306, 64683},
+	} {
+		chunks := readChunks(t, bytes.NewReader(data), ChunkerOptions{Algorithm: algo})
+
+		var got []int
+		for _, c := range chunks {
+			got = append(got, int(c.Offset))
+		}
+		if len(got) < len(want) {
+			t.Errorf("%s: got offsets %v", algo, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: got offsets %v", algo, got)
+				break
+			}
+		}
+	}
+}
+
+// TestChunkerShift checks that inserting bytes only changes the chunks
+// around them.
+func TestChunkerShift(t *testing.T) {
+	data := chunkerTestData(1 << 20)
+	shifted := append(append(append([]byte{}, data[:100000]...), "inserted"...), data[100000:]...)
+
+	for _, algo := range []string{ChunkFastCDC, ChunkRabin} {
+		opts := ChunkerOptions{Algorithm: algo}
+
+		seen := make(map[string]bool)
+		for _, c := range readChunks(t, bytes.NewReader(data), opts) {
+			seen[string(c.Multihash)] = true
+		}
+
+		var changed int
+		for _, c := range readChunks(t, bytes.NewReader(shifted), opts) {
+			if !seen[string(c.Multihash)] {
+				changed++
+			}
+		}
+		if changed == 0 || changed > 2 {
+			t.Errorf("%s: %d chunks changed", algo, changed)
+		}
+	}
+}
+
+func TestChunkerEmpty(t *testing.T) {
+	if chunks := readChunks(t, bytes.NewReader(nil), ChunkerOptions{}); len(chunks) != 0 {
+		t.Errorf("got %d chunks", len(chunks))
+	}
+
+	chunks := readChunks(t, bytes.NewReader([]byte("foo")), ChunkerOptions{})
+	if len(chunks) != 1 || chunks[0].Length != 3 {
+		t.Errorf("got chunks %v", chunks)
+	}
+}
+
+func TestChunkerErrors(t *testing.T) {
+	for _, opts := range []ChunkerOptions{
+		{AvgSize: 1000},
+		{AvgSize: 32},
+		{MinSize: 10000, AvgSize: 8192},
+		{AvgSize: 8192, MaxSize: 4096},
+		{MinSize: -1},
+	} {
+		if _, err := NewChunker(nil, SHA2_256, -1, opts); err != ErrInvalidChunkSizes {
+			t.Errorf("%+v: expected ErrInvalidChunkSizes, got: %v", opts, err)
+		}
+	}
+
+	if _, err := NewChunker(nil, SHA2_256, -1, ChunkerOptions{Algorithm: "foo"}); err == nil {
+		t.Error("expected an error for an unknown algorithm")
+	}
+	if _, err := NewChunker(nil, 0x9999, -1, ChunkerOptions{}); !errors.Is(err, ErrUnknownCode) {
+		t.Error("expected ErrUnknownCode, got: ", err)
+	}
+
+	c, _ := NewChunker(iotest.TimeoutReader(iotest.OneByteReader(bytes.NewReader(chunkerTestData(100000)))), SHA2_256, -1, ChunkerOptions{})
+	if _, err := c.Next(); err != iotest.ErrTimeout {
+		t.Error("expected iotest.ErrTimeout, got: ", err)
+	}
+}
@@ -0,0 +1,203 @@
+package multihash
+
+import (
+	"errors"
+	"hash"
+	"math"
+)
+
+// errors
+var (
+	ErrVarintNotMinimal = errors.New("uvarint: varint not minimally encoded")
+	ErrCodeNotAllowed   = errors.New("multihash code not allowed")
+)
+
+// MaxVarintLen is the maximum length of a varint in a multihash, as
+// set by the unsigned-varint spec. It limits codes and lengths to 63
+// bits.
+const MaxVarintLen = 9
+
+// DecodeOptions configures a Decoder.
+//
+// Whatever the options, a Decoder only accepts minimally encoded
+// varints of at most MaxVarintLen bytes, so that every multihash has
+// exactly one valid encoding. It also rejects digests longer than the
+// hash function can produce.
+type DecodeOptions struct {
+	// MaxLength is the largest digest length accepted, in bytes.
+	// Zero means 2^31-1, as for Decode.
+	MaxLength int
+
+	// Codes, if not empty, is the list of accepted codes. Other
+	// codes fail with ErrCodeNotAllowed.
+	Codes []uint64
+
+	// Lenient accepts codes unknown to this package, so that
+	// multihashes made with newer hash functions can still be
+	// decoded. Their Name is empty and their length is not checked
+	// (beyond MaxLength).
+	Lenient bool
+}
+
+// Decoder decodes multihashes strictly. It is safe for concurrent
+// use.
+type Decoder struct {
+	maxLength int
+	codes     map[uint64]struct{}
+	lenient   bool
+}
+
+// NewDecoder returns a Decoder with the given options.
+func NewDecoder(opts DecodeOptions) *Decoder {
+	d := &Decoder{
+		maxLength: opts.MaxLength,
+		lenient:   opts.Lenient,
+	}
+	if d.maxLength <= 0 {
+		d.maxLength = math.MaxInt32
+	}
+	if len(opts.Codes) > 0 {
+		d.codes = make(map[uint64]struct{}, len(opts.Codes))
+		for _, c := range opts.Codes {
+			d.codes[c] = struct{}{}
+		}
+	}
+	return d
+}
+
+// DecodeWithOptions is like Decode, but decodes strictly. See
+// DecodeOptions.
+func DecodeWithOptions(buf []byte, opts DecodeOptions) (*DecodedMultihash, error) {
+	return NewDecoder(opts).Decode(buf)
+}
+
+// Decode parses multihash bytes into a DecodedMultihash. Errors are
+// of type *Error.
+func (d *Decoder) Decode(buf []byte) (*DecodedMultihash, error) {
+	dm, err := d.decode(buf)
+	if err != nil {
+		return nil, err
+	}
+	return &dm, nil
+}
+
+// Cast is like the Cast function, but decodes strictly.
+func (d *Decoder) Cast(buf []byte) (Multihash, error) {
+	if _, err := d.decode(buf); err != nil {
+		return Multihash{}, err
+	}
+	return Multihash(buf), nil
+}
+
+func (d *Decoder) decode(buf []byte) (DecodedMultihash, error) {
+	var dm DecodedMultihash
+	total := len(buf)
+
+	if total < 3 {
+		return dm, &Error{Err: ErrTooShort, Length: -1, Actual: -1, Offset: total}
+	}
+
+	code, n, err := strictUvarint(buf)
+	if err != nil {
+		return dm, &Error{Err: err, Length: -1, Actual: -1, Offset: 0}
+	}
+
+	offset := n
+	length, n, err := strictUvarint(buf[offset:])
+	if err != nil {
+		return dm, &Error{Err: err, Code: code, Length: -1, Actual: -1, Offset: offset}
+	}
+	digest := buf[offset+n:]
+
+	if length > uint64(d.maxLength) {
+		e := &Error{Err: ErrTooLong, Code: code, Length: -1, Actual: len(digest), Offset: offset}
+		if length <= math.MaxInt32 {
+			e.Length = int(length)
+		}
+		return dm, e
+	}
+
+	if len(digest) != int(length) {
+		return dm, &Error{Err: ErrInconsistentLen{}, Code: code, Length: int(length), Actual: len(digest), Offset: offset + n}
+	}
+
+	if d.codes != nil {
+		if _, ok := d.codes[code]; !ok {
+			return dm, &Error{Err: ErrCodeNotAllowed, Code: code, Length: int(length), Actual: len(digest), Offset: 0}
+		}
+	}
+
+	name, _ := LookupName(code)
+	if !ValidCode(code) {
+		if !d.lenient {
+			return dm, &Error{Err: ErrUnknownCode, Code: code, Length: int(length), Actual: len(digest), Offset: 0}
+		}
+	} else if err := checkLength(code, int(length)); err != nil {
+		return dm, err
+	}
+
+	dm = DecodedMultihash{
+		Code:   code,
+		Name:   name,
+		Length: int(length),
+		Digest: digest,
+	}
+	return dm, nil
+}
+
+// strictUvarint reads a minimally encoded varint of at most
+// MaxVarintLen bytes, and returns it with the number of bytes read.
+func strictUvarint(buf []byte) (uint64, int, error) {
+	var x uint64
+	for i, b := range buf {
+		if i == MaxVarintLen {
+			return 0, 0, ErrVarintTooLong
+		}
+		x |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			if b == 0 && i > 0 {
+				return 0, 0, ErrVarintNotMinimal
+			}
+			return x, i + 1, nil
+		}
+	}
+	if len(buf) >= MaxVarintLen {
+		return 0, 0, ErrVarintTooLong
+	}
+	return 0, 0, ErrVarintBufferShort
+}
+
+// checkLength returns an error if the hash function of a code cannot
+// produce digests of the given length. Application specific codes
+// and the identity are not checked.
+func checkLength(code uint64, length int) error {
+	if code == ID {
+		return nil
+	}
+
+	f, ok := lookupCode(code)
+	if !ok || length <= f.defaultLength {
+		// digests can always be truncated
+		return nil
+	}
+
+	var h hash.Hash
+	var err error
+	if f.newHash != nil {
+		h, err = f.newHash(length)
+	} else {
+		// 32 bytes suit the keys of all the built-in keyed functions
+		h, err = f.newKeyedHash(make([]byte, 32), length)
+	}
+	if errors.Is(err, ErrLenNotSupported) {
+		return err
+	} else if err != nil {
+		// registered keyed hash functions may not like the probe key
+		return nil
+	}
+
+	if length > h.Size() {
+		return lengthError(code, length, h.Size())
+	}
+	return nil
+}
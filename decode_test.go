@@ -0,0 +1,106 @@
+package multihash
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestDecodeWithOptions(t *testing.T) {
+	sha := "12202c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	cases := []struct {
+		hex  string
+		opts DecodeOptions
+		err  error
+	}{
+		{sha, DecodeOptions{}, nil},
+		{"1104" + "0beec7b5", DecodeOptions{}, nil},
+
+		// non minimal varints
+		{"9200" + sha[2:], DecodeOptions{}, ErrVarintNotMinimal},
+		{"12a000" + sha[4:], DecodeOptions{}, ErrVarintNotMinimal},
+
+		// 10 byte varints
+		{"81808080808080808001" + sha[2:], DecodeOptions{}, ErrVarintTooLong},
+		{"808080808080808080", DecodeOptions{}, ErrVarintTooLong},
+		{"1280808080", DecodeOptions{}, ErrVarintBufferShort},
+
+		{sha, DecodeOptions{MaxLength: 20}, ErrTooLong},
+		{sha, DecodeOptions{MaxLength: 32}, nil},
+		{"1221" + sha[4:] + "00", DecodeOptions{}, ErrLenNotSupported},
+		{"a0e4ea0121" + sha[4:] + "00", DecodeOptions{}, ErrLenNotSupported}, // keyed blake2b-256
+		{sha[:20], DecodeOptions{}, ErrInconsistentLen{}},
+
+		{sha, DecodeOptions{Codes: []uint64{SHA1}}, ErrCodeNotAllowed},
+		{sha, DecodeOptions{Codes: []uint64{SHA1, SHA2_256}}, nil},
+
+		{"9fff0302aabb", DecodeOptions{}, ErrUnknownCode},
+		{"9fff0302aabb", DecodeOptions{Lenient: true}, nil},
+		{"9fff0302aabb", DecodeOptions{Lenient: true, Codes: []uint64{SHA2_256}}, ErrCodeNotAllowed},
+	}
+
+	for _, tc := range cases {
+		buf, err := hex.DecodeString(tc.hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dm, err := DecodeWithOptions(buf, tc.opts)
+		if tc.err == nil {
+			if err != nil {
+				t.Errorf("%s: %s", tc.hex, err)
+			}
+			continue
+		}
+
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %s, got: %v", tc.hex, tc.err, err)
+		}
+		if dm != nil {
+			t.Errorf("%s: expected no multihash on error", tc.hex)
+		}
+
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s: expected an *Error, got: %T", tc.hex, err)
+		}
+	}
+}
+
+func TestDecoderLenient(t *testing.T) {
+	d := NewDecoder(DecodeOptions{Lenient: true})
+
+	m, err := d.Cast([]byte{0x9f, 0xff, 0x03, 0x02, 0xaa, 0xbb})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dm, err := d.Decode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dm.Code != 0xff9f || dm.Name != "" || dm.Length != 2 {
+		t.Errorf("wrong lenient decoding: %+v", dm)
+	}
+}
+
+func TestDecoderMatchesDecode(t *testing.T) {
+	d := NewDecoder(DecodeOptions{})
+	for _, tc := range testCases {
+		m, err := tc.Multihash()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dm, err := d.Decode(m)
+		if err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+			continue
+		}
+
+		ref, _ := Decode(m)
+		if dm.Code != ref.Code || dm.Name != ref.Name || dm.Length != ref.Length {
+			t.Errorf("%s: strict decoding differs: %+v", tc.name, dm)
+		}
+	}
+}
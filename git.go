@@ -0,0 +1,353 @@
+package multihash
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Git object types.
+const (
+	GitBlob   = "blob"
+	GitTree   = "tree"
+	GitCommit = "commit"
+)
+
+// Git tree entry modes.
+const (
+	GitModeFile       = 0100644
+	GitModeExecutable = 0100755
+	GitModeSymlink    = 0120000
+	GitModeTree       = 040000
+	GitModeSubmodule  = 0160000
+)
+
+// SumGitObject computes the object ID git gives to data as an object
+// of the given type. The code must be SHA1 or SHA2_256, for the two
+// object formats of git, and the digest is never truncated: it is
+// what git hash-object prints.
+func SumGitObject(objType string, data []byte, code uint64) (Multihash, error) {
+	return SumGitObjectReader(objType, bytes.NewReader(data), int64(len(data)), code)
+}
+
+// SumGitObjectReader is like SumGitObject, for the first size bytes
+// read from r. Git objects start with their length, so it must be
+// known in advance. It returns io.ErrUnexpectedEOF if r is shorter.
+func SumGitObjectReader(objType string, r io.Reader, size int64, code uint64) (Multihash, error) {
+	h, err := newGitHasher(code)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(h, "%s %d\x00", objType, size)
+	n, err := io.CopyN(h, r, size)
+	if err == io.EOF && n < size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	return h.Multihash()
+}
+
+func newGitHasher(code uint64) (Hasher, error) {
+	if code != SHA1 && code != SHA2_256 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+	return NewHasher(code, -1)
+}
+
+// GitTreeEntry is an entry of a git tree: a file, a symbolic link, a
+// subtree or a submodule, whose object ID is Hash.
+type GitTreeEntry struct {
+	Mode uint32
+	Name string
+	Hash Multihash
+}
+
+// SumGitTree computes the object ID of a git tree. The entries do not
+// need to be sorted, and their hashes must use the same code as the
+// tree.
+func SumGitTree(entries []GitTreeEntry, code uint64) (Multihash, error) {
+	if _, err := newGitHasher(code); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]GitTreeEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return gitSortName(sorted[i]) < gitSortName(sorted[j])
+	})
+
+	var buf bytes.Buffer
+	for _, e := range sorted {
+		id, err := gitObjectID(e.Hash, code)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(&buf, "%o %s\x00", e.Mode, e.Name)
+		buf.Write(id)
+	}
+	return SumGitObject(GitTree, buf.Bytes(), code)
+}
+
+// gitObjectID returns the digest of m, which must be a full length
+// multihash with the given code.
+func gitObjectID(m Multihash, code uint64) ([]byte, error) {
+	dm, err := Decode(m)
+	if err != nil {
+		return nil, err
+	}
+	if dm.Code != code {
+		return nil, codeError(ErrInvalidParams, dm.Code)
+	}
+	if dl, _ := LookupDefaultLength(code); dm.Length != dl {
+		return nil, lengthError(code, dm.Length, dl)
+	}
+	return dm.Digest, nil
+}
+
+// gitSortName is the name git sorts a tree entry by: subtrees sort
+// as if their name ended with a slash.
+func gitSortName(e GitTreeEntry) string {
+	if e.Mode == GitModeTree {
+		return e.Name + "/"
+	}
+	return e.Name
+}
+
+// SumGitDirectory computes the object ID of the git tree of the
+// directory at path, as git would store it once added: regular files
+// are blobs, executable or not, symbolic links are blobs of their
+// target, and nested repositories, such as submodules, are gitlinks
+// to the commit checked out in them. Like git, it skips empty
+// directories, other types of files and .git directories.
+func SumGitDirectory(path string, code uint64) (Multihash, error) {
+	entries, err := gitDirectoryEntries(path, code)
+	if err != nil {
+		return nil, err
+	}
+	return SumGitTree(entries, code)
+}
+
+func gitDirectoryEntries(path string, code uint64) ([]GitTreeEntry, error) {
+	infos, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []GitTreeEntry
+	for _, fi := range infos {
+		if fi.Name() == ".git" {
+			continue
+		}
+		p := filepath.Join(path, fi.Name())
+
+		var e GitTreeEntry
+		switch mode := fi.Mode(); {
+		case mode.IsRegular():
+			e.Mode = GitModeFile
+			if mode&0100 != 0 {
+				e.Mode = GitModeExecutable
+			}
+			e.Hash, err = sumGitFile(p, fi.Size(), code)
+
+		case mode&os.ModeSymlink != 0:
+			var target string
+			target, err = os.Readlink(p)
+			if err == nil {
+				e.Mode = GitModeSymlink
+				e.Hash, err = SumGitObject(GitBlob, []byte(target), code)
+			}
+
+		case mode.IsDir():
+			var head Multihash
+			if head, err = gitRepositoryHead(p, code); err != nil || head != nil {
+				e.Mode = GitModeSubmodule
+				e.Hash = head
+				break
+			}
+
+			var sub []GitTreeEntry
+			sub, err = gitDirectoryEntries(p, code)
+			if err == nil && len(sub) == 0 {
+				continue
+			}
+			if err == nil {
+				e.Mode = GitModeTree
+				e.Hash, err = SumGitTree(sub, code)
+			}
+
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		e.Name = fi.Name()
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// gitRepositoryHead returns the commit checked out in the repository
+// at path, or nil if path is not a repository. Like git, it takes any
+// directory with a .git directory, or with a .git file pointing to
+// one, for a repository.
+func gitRepositoryHead(path string, code uint64) (Multihash, error) {
+	gitDir := filepath.Join(path, ".git")
+	fi, err := os.Stat(gitDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !fi.IsDir() {
+		// a submodule: "gitdir: <path>" of the git directory
+		b, err := ioutil.ReadFile(gitDir)
+		if err != nil {
+			return nil, err
+		}
+		s := strings.TrimSpace(string(b))
+		if !strings.HasPrefix(s, "gitdir: ") {
+			return nil, fmt.Errorf("%s: not a gitdir file", gitDir)
+		}
+		gitDir = strings.TrimPrefix(s, "gitdir: ")
+		if !filepath.IsAbs(gitDir) {
+			gitDir = filepath.Join(path, gitDir)
+		}
+	}
+
+	// follow symbolic refs, such as "ref: refs/heads/master" in HEAD
+	ref := "HEAD"
+	for i := 0; i < 5; i++ {
+		v, err := readGitRef(gitDir, ref)
+		if err != nil {
+			return nil, err
+		}
+		if v == "" {
+			return nil, fmt.Errorf("%s: no commit checked out", path)
+		}
+		if !strings.HasPrefix(v, "ref: ") {
+			id, err := hex.DecodeString(v)
+			if dl, _ := LookupDefaultLength(code); err != nil || len(id) != dl {
+				return nil, fmt.Errorf("%s: %s is not a %s object ID", path, v, Codes[code])
+			}
+			return Encode(id, code)
+		}
+		ref = strings.TrimPrefix(v, "ref: ")
+	}
+	return nil, fmt.Errorf("%s: too many levels of symbolic refs", path)
+}
+
+// readGitRef reads a ref of the git directory, as a loose ref or from
+// packed-refs. It returns "" if the ref does not exist.
+func readGitRef(gitDir, ref string) (string, error) {
+	if ref != "HEAD" {
+		// the refs of a linked worktree are in the main git directory
+		if b, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+			common := strings.TrimSpace(string(b))
+			if !filepath.IsAbs(common) {
+				common = filepath.Join(gitDir, common)
+			}
+			gitDir = common
+		}
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref)))
+	if err == nil {
+		return strings.TrimSpace(string(b)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	f, err := os.Open(filepath.Join(gitDir, "packed-refs"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// lines are "<id> <ref>", with comments and peeled tags
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0], nil
+		}
+	}
+	return "", s.Err()
+}
+
+func sumGitFile(path string, size int64, code uint64) (Multihash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return SumGitObjectReader(GitBlob, f, size, code)
+}
+
+// GitSignature is the author or committer of a git commit.
+type GitSignature struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
+func (s GitSignature) String() string {
+	return fmt.Sprintf("%s <%s> %d %s", s.Name, s.Email, s.When.Unix(), s.When.Format("-0700"))
+}
+
+// GitCommitObject describes a git commit. Its tree and parents must
+// use the code of the commit.
+type GitCommitObject struct {
+	Tree      Multihash
+	Parents   []Multihash
+	Author    GitSignature
+	Committer GitSignature
+	Message   string
+}
+
+// SumGitCommit computes the object ID of a git commit.
+func SumGitCommit(c *GitCommitObject, code uint64) (Multihash, error) {
+	if _, err := newGitHasher(code); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	id, err := gitObjectID(c.Tree, code)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&buf, "tree %x\n", id)
+
+	for _, p := range c.Parents {
+		id, err := gitObjectID(p, code)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "parent %x\n", id)
+	}
+
+	buf.WriteString("author " + c.Author.String() + "\n")
+	buf.WriteString("committer " + c.Committer.String() + "\n")
+	buf.WriteString("\n" + c.Message)
+	return SumGitObject(GitCommit, buf.Bytes(), code)
+}
@@ -0,0 +1,208 @@
+package multihash
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// gitIDs are the object IDs git computes for the test repository in
+// both object formats.
+var gitIDs = map[uint64]map[string]string{
+	SHA1: {
+		"blob":      "3b18e512dba79e4c8300dd08aeb37f8e728b8dad",
+		"emptyblob": "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391",
+		"emptytree": "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+		"tree":      "dfa73cb1443acf670ba99a6a32587e2b3bd5a536",
+		"commit1":   "e3f1ba05c9033bf080d3ff5f19e3ac065237c124",
+		"commit2":   "b8fa92c7c1f082dfc5786f9fbfdb2a3324beb511",
+	},
+	SHA2_256: {
+		"blob":      "0bd69098bd9b9cc5934a610ab65da429b525361147faa7b5b922919e9a23143d",
+		"emptyblob": "473a0f4c3be8a93681a267e3b1e9a7dcda1185436fe141f7749120a303721813",
+		"emptytree": "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321",
+		"tree":      "f1aea667b40dcf362d50679a91e0811d90e71eeebc1d2941f71ff45af88ee967",
+		"commit1":   "09e2398b6cdc47aadeaa2d0fa3942404cc389fdc74d14e3bc2e628bbadabb62e",
+		"commit2":   "ec7c9d6e672ef11709092ee99dd6152124c209f36cbd22960c227d25992b303b",
+	},
+}
+
+func gitID(t *testing.T, m Multihash) string {
+	t.Helper()
+
+	dm, err := Decode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(dm.Digest)
+}
+
+// makeGitTestDirectory creates the files of the test repository.
+func makeGitTestDirectory(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "multihash-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeGitTestFiles(t, dir, map[string]string{
+		"hello.txt": "hello world\n",
+		"run.sh":    "#!/bin/sh\necho hi\n",
+		"a/b/c":     "nested",
+		"a.b":       "dot",
+		".git/HEAD": "ignored",
+	})
+
+	if err := os.Chmod(filepath.Join(dir, "run.sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("hello.txt", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeGitTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGitObjects(t *testing.T) {
+	dir := makeGitTestDirectory(t)
+	defer os.RemoveAll(dir)
+
+	author := GitSignature{"A U Thor", "author@example.com", time.Unix(1112911993, 0).In(time.FixedZone("", -7*3600))}
+	committer := GitSignature{"C O Mitter", "committer@example.com", time.Unix(1112912053, 0).In(time.FixedZone("", 2*3600))}
+
+	for code, ids := range gitIDs {
+		name := Codes[code]
+
+		blob, err := SumGitObject(GitBlob, []byte("hello world\n"), code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := gitID(t, blob); id != ids["blob"] {
+			t.Errorf("%s: wrong blob: %s", name, id)
+		}
+
+		empty, _ := SumGitObject(GitBlob, nil, code)
+		if id := gitID(t, empty); id != ids["emptyblob"] {
+			t.Errorf("%s: wrong empty blob: %s", name, id)
+		}
+
+		emptyTree, _ := SumGitTree(nil, code)
+		if id := gitID(t, emptyTree); id != ids["emptytree"] {
+			t.Errorf("%s: wrong empty tree: %s", name, id)
+		}
+
+		tree, err := SumGitDirectory(dir, code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := gitID(t, tree); id != ids["tree"] {
+			t.Errorf("%s: wrong tree: %s", name, id)
+		}
+
+		c1, err := SumGitCommit(&GitCommitObject{
+			Tree:      tree,
+			Author:    author,
+			Committer: committer,
+			Message:   "initial\n",
+		}, code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := gitID(t, c1); id != ids["commit1"] {
+			t.Errorf("%s: wrong commit: %s", name, id)
+		}
+
+		c2, err := SumGitCommit(&GitCommitObject{
+			Tree:      tree,
+			Parents:   []Multihash{c1},
+			Author:    author,
+			Committer: committer,
+			Message:   "second\n",
+		}, code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := gitID(t, c2); id != ids["commit2"] {
+			t.Errorf("%s: wrong commit with a parent: %s", name, id)
+		}
+	}
+}
+
+func TestGitObjectErrors(t *testing.T) {
+	if _, err := SumGitObject(GitBlob, nil, SHA2_512); !errors.Is(err, ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+
+	if _, err := SumGitObjectReader(GitBlob, strings.NewReader("foo"), 4, SHA1); err == nil {
+		t.Error("a short reader should fail")
+	}
+
+	// the entries must use the code of the tree, untruncated
+	sha1Blob, _ := SumGitObject(GitBlob, nil, SHA1)
+	if _, err := SumGitTree([]GitTreeEntry{{GitModeFile, "foo", sha1Blob}}, SHA2_256); !errors.Is(err, ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+	truncated, _ := Sum(nil, SHA1, 10)
+	if _, err := SumGitTree([]GitTreeEntry{{GitModeFile, "foo", truncated}}, SHA1); !errors.Is(err, ErrLenNotSupported) {
+		t.Error("expected ErrLenNotSupported, got: ", err)
+	}
+}
+
+func TestGitDirectoryGitlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multihash-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a nested repository with a packed branch, and a submodule whose
+	// git directory is that of the nested repository
+	writeGitTestFiles(t, dir, map[string]string{
+		"top.txt":                "hi\n",
+		"inner/f":                "x\n",
+		"inner/.git/HEAD":        "ref: refs/heads/master\n",
+		"inner/.git/packed-refs": "# pack-refs with: peeled fully-peeled sorted\n7e081129bc143a2c4c13f13255890a762e5b055b refs/heads/master\n",
+		"sm/file":                "y\n",
+		"sm/.git":                "gitdir: ../inner/.git\n",
+	})
+
+	// git add and git write-tree give this tree, of two gitlinks
+	tree, err := SumGitDirectory(dir, SHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := gitID(t, tree); id != "24ed3f0ad5ea5d3c95068fe2253b096c12f9326f" {
+		t.Errorf("wrong tree: %s", id)
+	}
+
+	// the commit must use the code of the tree
+	if _, err := SumGitDirectory(dir, SHA2_256); err == nil {
+		t.Error("a sha1 gitlink in a sha2-256 tree should fail")
+	}
+
+	// like git add, fail for a repository without commits
+	writeGitTestFiles(t, dir, map[string]string{
+		"unborn/.git/HEAD": "ref: refs/heads/master\n",
+	})
+	if _, err := SumGitDirectory(dir, SHA1); err == nil {
+		t.Error("a repository without commits should fail")
+	}
+}
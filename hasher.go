@@ -0,0 +1,105 @@
+package multihash
+
+import (
+	"hash"
+)
+
+// Hasher is a hash.Hash which produces multihashes. Data is fed
+// through Write, so arbitrarily large inputs can be hashed in
+// constant memory. Sum and Size refer to the (possibly truncated)
+// digest, not to the encoded multihash.
+type Hasher interface {
+	hash.Hash
+
+	// Multihash returns the multihash of the data written so far.
+	// It does not change the underlying hash state.
+	Multihash() (Multihash, error)
+}
+
+// NewHasher returns a Hasher for the given multihash code. The
+// length parameter indicates the length of the resulting digest
+// and passing a negative value use default length values for the
+// selected hash function. Identity digests are never truncated: the
+// Multihash of an ID Hasher fails unless exactly length bytes were
+// written.
+func NewHasher(code uint64, length int) (Hasher, error) {
+	f, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.newHash == nil {
+		return nil, codeError(ErrKeyRequired, code)
+	}
+
+	h, err := f.newHash(length)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(code, length, h)
+}
+
+// lookupHashFunction finds the hash function of a code, and resolves
+// a negative length to its default length.
+func lookupHashFunction(code uint64, length int) (*hashFunction, int, error) {
+	if !ValidCode(code) {
+		return nil, 0, codeError(ErrUnknownCode, code)
+	}
+
+	f, ok := lookupCode(code)
+	if !ok {
+		return nil, 0, codeError(ErrSumNotSupported, code)
+	}
+
+	if length < 0 {
+		length = f.defaultLength
+	}
+	return f, length, nil
+}
+
+func newMhHasher(code uint64, length int, h hash.Hash) (Hasher, error) {
+	if code != ID && length > h.Size() {
+		return nil, lengthError(code, length, h.Size())
+	}
+
+	return &mhHasher{code: code, length: length, h: h}, nil
+}
+
+type mhHasher struct {
+	code   uint64
+	length int
+	h      hash.Hash
+}
+
+func (m *mhHasher) Write(p []byte) (int, error) {
+	return m.h.Write(p)
+}
+
+func (m *mhHasher) Sum(b []byte) []byte {
+	d := m.h.Sum(nil)
+	if m.code != ID && len(d) > m.length {
+		d = d[:m.length]
+	}
+	return append(b, d...)
+}
+
+func (m *mhHasher) Reset() {
+	m.h.Reset()
+}
+
+func (m *mhHasher) Size() int {
+	return m.length
+}
+
+func (m *mhHasher) BlockSize() int {
+	return m.h.BlockSize()
+}
+
+func (m *mhHasher) Multihash() (Multihash, error) {
+	d := m.Sum(nil)
+	if len(d) != m.length {
+		return nil, lengthError(m.code, m.length, len(d))
+	}
+	return Encode(d, m.code)
+}
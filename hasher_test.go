@@ -0,0 +1,140 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"hash"
+	"io"
+	"testing"
+)
+
+// make sure Hasher can be used wherever a hash.Hash is expected.
+var _ hash.Hash = Hasher(nil)
+
+func TestHasher(t *testing.T) {
+	for _, tc := range sumTestCases {
+		m1, err := FromHexString(tc.hex)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		h, err := NewHasher(tc.code, tc.length)
+		if err != nil {
+			t.Error(tc.code, "new hasher failed.", err)
+			continue
+		}
+
+		// write one byte at a time to exercise buffering.
+		for _, b := range []byte(tc.input) {
+			h.Write([]byte{b})
+		}
+
+		m2, err := h.Multihash()
+		if err != nil {
+			t.Error(tc.code, "hasher failed.", err)
+			continue
+		}
+
+		if !bytes.Equal(m1, m2) {
+			t.Error(tc.code, Codes[tc.code], "hasher failed.", m1, m2)
+			t.Error(hex.EncodeToString(m2))
+		}
+
+		dm, err := Decode(m2)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !bytes.Equal(h.Sum(nil), dm.Digest) {
+			t.Error(tc.code, "Sum does not match multihash digest")
+		}
+		if h.Size() != dm.Length {
+			t.Error(tc.code, "Size does not match multihash length")
+		}
+	}
+}
+
+func TestHasherReset(t *testing.T) {
+	h, err := NewHasher(SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h.Write([]byte("some other data"))
+	h.Reset()
+	h.Write([]byte("foo"))
+
+	m1, err := h.Multihash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m2, err := Sum([]byte("foo"), SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(m1, m2) {
+		t.Error("hasher did not reset", m1, m2)
+	}
+}
+
+func TestHasherLarge(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 10000)
+
+	for _, code := range []uint64{SHA1, SHA2_512, SHA3_256, BLAKE2B_MAX, SKEIN512_MAX, SKEIN1024_MAX - 100, SHAKE_128} {
+		h, err := NewHasher(code, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := io.CopyBuffer(h, bytes.NewReader(data), make([]byte, 1000)); err != nil {
+			t.Fatal(err)
+		}
+
+		m1, err := h.Multihash()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m2, err := Sum(data, code, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(m1, m2) {
+			t.Error(code, "streamed sum does not match")
+		}
+	}
+}
+
+func TestHasherBadLength(t *testing.T) {
+	if _, err := NewHasher(SHA1, 21); err == nil {
+		t.Error("sha1 should not allow 21 byte digests")
+	}
+
+	h, err := NewHasher(ID, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte("foo"))
+	if _, err := h.Multihash(); err == nil {
+		t.Error("id digest shorter than length should fail")
+	}
+	h.Write([]byte("bar"))
+	if _, err := h.Multihash(); err == nil {
+		t.Error("id digest longer than length should fail")
+	}
+}
+
+func BenchmarkHasher(b *testing.B) {
+	data := make([]byte, 1<<16)
+	h, _ := NewHasher(SHA2_256, -1)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		h.Write(data)
+		h.Multihash()
+	}
+}
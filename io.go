@@ -1,8 +1,8 @@
 package multihash
 
 import (
+	"bufio"
 	"encoding/binary"
-	"errors"
 	"io"
 	"math"
 )
@@ -23,9 +23,32 @@ type Writer interface {
 	WriteMultihash(Multihash) error
 }
 
+// BoundedReader is a Reader which decodes multihashes strictly,
+// within the limits given by its DecodeOptions. It never allocates
+// more memory than the digests it actually reads.
+type BoundedReader interface {
+	Reader
+
+	// ReadMultihashInto reads a multihash into the memory of dst,
+	// which is only reallocated if it is too small, and returns it.
+	ReadMultihashInto(dst []byte) (Multihash, error)
+}
+
 // NewReader wraps an io.Reader with a multihash.Reader
 func NewReader(r io.Reader) Reader {
-	return &mhReader{r}
+	return &mhReader{r: r}
+}
+
+// NewBoundedReader wraps an io.Reader with a BoundedReader. Digests
+// longer than opts.MaxLength, or with a code which opts do not allow,
+// are rejected as soon as their header is read. The BoundedReader
+// buffers its input, so it may read more than it returns from r.
+func NewBoundedReader(r io.Reader, opts DecodeOptions) BoundedReader {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &boundedReader{r: br, d: NewDecoder(opts)}
 }
 
 // NewWriter wraps an io.Writer with a multihash.Writer
@@ -35,6 +58,7 @@ func NewWriter(w io.Writer) Writer {
 
 type mhReader struct {
 	r io.Reader
+	b [1]byte
 }
 
 func (r *mhReader) Read(buf []byte) (n int, err error) {
@@ -45,12 +69,10 @@ func (r *mhReader) ReadByte() (byte, error) {
 	if br, ok := r.r.(io.ByteReader); ok {
 		return br.ReadByte()
 	}
-	b := make([]byte, 1)
-	_, err := r.r.Read(b)
-	if err != nil {
+	if _, err := io.ReadFull(r.r, r.b[:]); err != nil {
 		return 0, err
 	}
-	return b[0], nil
+	return r.b[0], nil
 }
 
 func (r *mhReader) ReadMultihash() (Multihash, error) {
@@ -64,23 +86,132 @@ func (r *mhReader) ReadMultihash() (Multihash, error) {
 		return nil, err
 	}
 	if length > math.MaxInt32 {
-		return nil, errors.New("digest too long, supporting only <= 2^31-1")
+		return nil, &Error{Err: ErrTooLong, Code: code, Length: -1, Actual: -1, Offset: -1}
+	}
+
+	buf := appendHeader(nil, code, length)
+	buf, err = readDigest(r.r, buf, int(length))
+	if err != nil {
+		return nil, err
 	}
 
-	pre := make([]byte, 2*binary.MaxVarintLen64)
-	spot := pre
-	n := binary.PutUvarint(spot, code)
-	spot = pre[n:]
-	n += binary.PutUvarint(spot, length)
+	return Cast(buf)
+}
+
+type boundedReader struct {
+	r *bufio.Reader
+	d *Decoder
+}
+
+func (r *boundedReader) Read(buf []byte) (n int, err error) {
+	return r.r.Read(buf)
+}
 
-	buf := make([]byte, int(length)+n)
-	copy(buf, pre[:n])
+func (r *boundedReader) ReadMultihash() (Multihash, error) {
+	return r.ReadMultihashInto(nil)
+}
 
-	if _, err := io.ReadFull(r.r, buf[n:]); err != nil {
+func (r *boundedReader) ReadMultihashInto(dst []byte) (Multihash, error) {
+	code, err := readStrictUvarint(r.r, true)
+	if err != nil {
 		return nil, err
 	}
 
-	return Cast(buf)
+	length, err := readStrictUvarint(r.r, false)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(r.d.maxLength) {
+		e := &Error{Err: ErrTooLong, Code: code, Length: -1, Actual: -1, Offset: -1}
+		if length <= math.MaxInt32 {
+			e.Length = int(length)
+		}
+		return nil, e
+	}
+	if r.d.codes != nil {
+		if _, ok := r.d.codes[code]; !ok {
+			return nil, &Error{Err: ErrCodeNotAllowed, Code: code, Length: int(length), Actual: -1, Offset: -1}
+		}
+	}
+
+	buf := appendHeader(dst[:0], code, length)
+	buf, err = readDigest(r.r, buf, int(length))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.d.Cast(buf)
+}
+
+// appendHeader appends the code and length varints of a multihash.
+func appendHeader(buf []byte, code, length uint64) []byte {
+	var pre [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(pre[:], code)
+	n += binary.PutUvarint(pre[n:], length)
+	return append(buf, pre[:n]...)
+}
+
+// readDigestChunk bounds how much readDigest allocates ahead of the
+// data actually read, so that a bogus length cannot exhaust memory.
+const readDigestChunk = 64 << 10
+
+// readDigest appends length bytes read from r to buf.
+func readDigest(r io.Reader, buf []byte, length int) ([]byte, error) {
+	for length > 0 {
+		n := length
+		if n > readDigestChunk && len(buf)+n > cap(buf) {
+			n = readDigestChunk
+		}
+
+		start := len(buf)
+		if start+n > cap(buf) {
+			c := 2 * cap(buf)
+			if c < start+n {
+				c = start + n
+			}
+			if c > start+length {
+				c = start + length
+			}
+			nbuf := make([]byte, start, c)
+			copy(nbuf, buf)
+			buf = nbuf
+		}
+		buf = buf[:start+n]
+
+		if _, err := io.ReadFull(r, buf[start:]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		length -= n
+	}
+	return buf, nil
+}
+
+// readStrictUvarint reads a varint with the rules of strictUvarint.
+// An io.EOF before the first byte of the first varint of a multihash
+// is returned as is, any other as io.ErrUnexpectedEOF.
+func readStrictUvarint(r io.ByteReader, first bool) (uint64, error) {
+	var x uint64
+	for i := 0; i < MaxVarintLen; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && (i > 0 || !first) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+
+		x |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			if b == 0 && i > 0 {
+				return 0, ErrVarintNotMinimal
+			}
+			return x, nil
+		}
+	}
+	return 0, ErrVarintTooLong
 }
 
 type mhWriter struct {
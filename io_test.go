@@ -2,7 +2,9 @@ package multihash
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"math"
 	"testing"
 )
 
@@ -67,3 +69,109 @@ func TestWriter(t *testing.T) {
 		}
 	}
 }
+
+func TestBoundedReader(t *testing.T) {
+	var buf bytes.Buffer
+	for _, tc := range testCases {
+		m, err := tc.Multihash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write([]byte(m))
+	}
+
+	r := NewBoundedReader(&buf, DecodeOptions{})
+	dst := make([]byte, 0, 256)
+	for _, tc := range testCases {
+		h, _ := tc.Multihash()
+
+		h2, err := r.ReadMultihashInto(dst)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !bytes.Equal(h, h2) {
+			t.Error("h and h2 should be equal")
+		}
+		if &h2[0] != &dst[:1][0] {
+			t.Error("ReadMultihashInto should reuse dst")
+		}
+	}
+
+	if _, err := r.ReadMultihash(); err != io.EOF {
+		t.Error("expected io.EOF at the end of the stream, got: ", err)
+	}
+}
+
+func TestBoundedReaderLimits(t *testing.T) {
+	cases := []struct {
+		in   []byte
+		opts DecodeOptions
+		err  error
+	}{
+		// a 2 GB identity multihash, with no data behind it
+		{[]byte{0x00, 0xff, 0xff, 0xff, 0xff, 0x07}, DecodeOptions{MaxLength: 1024}, ErrTooLong},
+		{[]byte{0x12, 0x20}, DecodeOptions{Codes: []uint64{SHA1}}, ErrCodeNotAllowed},
+		{[]byte{0x92, 0x00, 0x20}, DecodeOptions{}, ErrVarintNotMinimal},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, DecodeOptions{}, ErrVarintTooLong},
+		{[]byte{0x12, 0x20, 0xaa}, DecodeOptions{}, io.ErrUnexpectedEOF},
+		{[]byte{0x12}, DecodeOptions{}, io.ErrUnexpectedEOF},
+		{[]byte{0x11, 0x16, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, DecodeOptions{}, ErrLenNotSupported},
+	}
+
+	for i, tc := range cases {
+		r := NewBoundedReader(bytes.NewReader(tc.in), tc.opts)
+		if _, err := r.ReadMultihash(); !errors.Is(err, tc.err) {
+			t.Errorf("%d: expected %s, got: %v", i, tc.err, err)
+		}
+	}
+}
+
+func TestBoundedReaderTooLong(t *testing.T) {
+	cases := []struct {
+		in     []byte
+		length int
+	}{
+		{[]byte{0x00, 0xff, 0xff, 0xff, 0xff, 0x07}, math.MaxInt32},
+		{[]byte{0x12, 0x81, 0x08}, 1025},
+		{[]byte{0x00, 0x80, 0x80, 0x80, 0x80, 0x08}, -1},
+	}
+
+	for _, tc := range cases {
+		r := NewBoundedReader(bytes.NewReader(tc.in), DecodeOptions{MaxLength: 1024})
+		_, err := r.ReadMultihash()
+
+		var e *Error
+		if !errors.As(err, &e) || e.Err != ErrTooLong {
+			t.Errorf("%x: expected ErrTooLong, got: %v", tc.in, err)
+			continue
+		}
+		if e.Length != tc.length || e.Actual != -1 {
+			t.Errorf("%x: got length %d, actual %d", tc.in, e.Length, e.Actual)
+		}
+	}
+}
+
+func TestReaderHugeLength(t *testing.T) {
+	// the length is only trusted as far as there is data to read
+	r := NewReader(bytes.NewReader([]byte{0x00, 0xff, 0xff, 0xff, 0xff, 0x07, 0xaa}))
+	if _, err := r.ReadMultihash(); err != io.ErrUnexpectedEOF {
+		t.Error("expected io.ErrUnexpectedEOF, got: ", err)
+	}
+}
+
+func BenchmarkBoundedReader(b *testing.B) {
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+	data := bytes.Repeat(m, 1024)
+	dst := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r := NewBoundedReader(bytes.NewReader(data), DecodeOptions{})
+		for j := 0; j < 1024; j++ {
+			if _, err := r.ReadMultihashInto(dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package multihash
+
+import (
+	"hash"
+)
+
+// This is KangarooTwelve (RFC 9861): TurboSHAKE128, which is Keccak
+// with 12 rounds, in a tree of 8 KiB chunks.
+
+const k12ChunkSize = 8192
+
+// NewK12Hasher returns a Hasher for KANGAROOTWELVE with the given
+// customization string.
+func NewK12Hasher(length int, custom []byte) (Hasher, error) {
+	_, length, err := lookupHashFunction(KANGAROOTWELVE, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkXOFLength(KANGAROOTWELVE, length); err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(KANGAROOTWELVE, length, newK12(custom, length))
+}
+
+func k12HashFunc(length int) (hash.Hash, error) {
+	if err := checkXOFLength(KANGAROOTWELVE, length); err != nil {
+		return nil, err
+	}
+	return newK12(nil, length), nil
+}
+
+// k12 absorbs the first chunk in the final node, and the following
+// ones in leaves whose chaining values are absorbed by the final node.
+type k12 struct {
+	final  sponge
+	leaf   sponge
+	custom []byte
+
+	n      int64 // bytes written
+	nleaf  int   // bytes in the current leaf
+	leaves uint64
+}
+
+func newK12(custom []byte, size int) *k12 {
+	k := &k12{custom: custom}
+	k.final = *newKeccak(168, 0, size)
+	k.final.rounds = 12
+	k.leaf = *newKeccak(168, 0x0b, 32)
+	k.leaf.rounds = 12
+	return k
+}
+
+func (k *k12) Reset() {
+	k.final.Reset()
+	k.leaf.Reset()
+	k.n = 0
+	k.nleaf = 0
+	k.leaves = 0
+}
+
+func (k *k12) Size() int      { return k.final.size }
+func (k *k12) BlockSize() int { return k12ChunkSize }
+
+func (k *k12) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if k.n < k12ChunkSize {
+			c := k12ChunkSize - int(k.n)
+			if c > len(p) {
+				c = len(p)
+			}
+			k.final.Write(p[:c])
+			k.n += int64(c)
+			p = p[c:]
+			continue
+		}
+
+		if k.n == k12ChunkSize {
+			// the first chunk is followed by the chaining values
+			k.final.Write([]byte{3, 0, 0, 0, 0, 0, 0, 0})
+		}
+		if k.nleaf == k12ChunkSize {
+			k.flush()
+		}
+
+		c := k12ChunkSize - k.nleaf
+		if c > len(p) {
+			c = len(p)
+		}
+		k.leaf.Write(p[:c])
+		k.nleaf += c
+		k.n += int64(c)
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// flush absorbs the chaining value of the current leaf.
+func (k *k12) flush() {
+	k.final.Write(k.leaf.squeeze(32))
+	k.leaf.Reset()
+	k.nleaf = 0
+	k.leaves++
+}
+
+func (k *k12) Sum(in []byte) []byte {
+	d := *k
+	d.Write(d.custom)
+	d.Write(k12LengthEncode(uint64(len(d.custom))))
+
+	if d.n <= k12ChunkSize {
+		d.final.ds = 0x07
+	} else {
+		if d.nleaf > 0 {
+			d.flush()
+		}
+		d.final.Write(k12LengthEncode(d.leaves))
+		d.final.Write([]byte{0xff, 0xff})
+		d.final.ds = 0x06
+	}
+	return append(in, d.final.squeeze(d.final.size)...)
+}
+
+// k12LengthEncode is the length_encode function of KangarooTwelve:
+// x in big endian without leading zeros, followed by its length.
+func k12LengthEncode(x uint64) []byte {
+	var b []byte
+	for ; x > 0; x >>= 8 {
+		b = append([]byte{byte(x)}, b...)
+	}
+	return append(b, byte(len(b)))
+}
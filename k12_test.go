@@ -0,0 +1,72 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestK12Vectors(t *testing.T) {
+	cases := []struct {
+		msg    []byte
+		custom []byte
+		length int
+		hex    string
+	}{
+		// RFC 9861
+		{nil, nil, 32, "1ac2d450fc3b4205d19da7bfca1b37513c0803577ac7167f06fe2ce1f0ef39e5"},
+		{nil, nil, 64, "1ac2d450fc3b4205d19da7bfca1b37513c0803577ac7167f06fe2ce1f0ef39e54269c056b8c82e48276038b6d292966cc07a3d4645272e31ff38508139eb0a71"},
+		{patternInput(17), nil, 32, "6bf75fa2239198db4772e36478f8e19b0f371205f6a9a93a273f51df37122888"},
+		{patternInput(17 * 17), nil, 32, "0c315ebcdedbf61426de7dcf8fb725d1e74675d7f5327a5067f367b108ecb67c"},
+		{patternInput(17 * 17 * 17), nil, 32, "cb552e2ec77d9910701d578b457ddf772c12e322e4ee7fe417f92c758f0d59d0"},
+		{patternInput(17 * 17 * 17 * 17), nil, 32, "8701045e22205345ff4dda05555cbb5c3af1a771c2b89baef37db43d9998b9fe"},
+		{patternInput(17 * 17 * 17 * 17 * 17), nil, 32, "844d610933b1b9963cbdeb5ae3b6b05cc7cbd67ceedf883eb678a0a8e0371682"},
+		{patternInput(17 * 17 * 17 * 17 * 17 * 17), nil, 32, "3c390782a8a4e89fa6367f72feaaf13255c8d95878481d3cd8ce85f58e880af8"},
+		{nil, patternInput(1), 32, "fab658db63e94a246188bf7af69a133045f46ee984c56e3c3328caaf1aa1a583"},
+		{[]byte{0xff}, patternInput(41), 32, "d848c5068ced736f4462159b9867fd4c20b808acc3d5bc48e0b06ba0a3762ec4"},
+		{[]byte{0xff, 0xff, 0xff}, patternInput(41 * 41), 32, "c389e5009ae57120854c2e8c64670ac01358cf4c1baf89447a724234dc7ced74"},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, patternInput(41 * 41 * 41), 32, "75d2f86a2e644566726b4fbcfc5657b9dbcf070c7b0dca06450ab291d7443bcf"},
+
+		// chunk boundaries
+		{patternInput(k12ChunkSize), nil, 16, "48f256f6772f9edfb6a8b661ec92dc93"},
+		{patternInput(k12ChunkSize + 1), nil, 16, "bb66fe72eaea5179418d5295ee134485"},
+		{patternInput(2 * k12ChunkSize), nil, 16, "82778f7f7234c83352e76837b721fbdb"},
+		{patternInput(2*k12ChunkSize + 1), nil, 16, "5f8d2b943922b451842b4e82740d0236"},
+		{patternInput(3 * k12ChunkSize), nil, 16, "f4082a8fe7d1635aa042cd1da63bf235"},
+		{patternInput(3*k12ChunkSize + 1), nil, 16, "38cb940999aca742d69dd79298c6051c"},
+	}
+
+	for i, tc := range cases {
+		h, err := NewK12Hasher(tc.length, tc.custom)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// write in uneven pieces, after a reset
+		h.Write([]byte("garbage"))
+		h.Reset()
+		for p := tc.msg; len(p) > 0; {
+			n := 1000
+			if n > len(p) {
+				n = len(p)
+			}
+			h.Write(p[:n])
+			p = p[n:]
+		}
+
+		if d := hex.EncodeToString(h.Sum(nil)); d != tc.hex {
+			t.Errorf("%d: got %s", i, d)
+		}
+
+		if tc.custom == nil {
+			m, err := Sum(tc.msg, KANGAROOTWELVE, tc.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dm, _ := Decode(m)
+			if !bytes.Equal(dm.Digest, h.Sum(nil)) {
+				t.Errorf("%d: Sum differs from the streamed digest", i)
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package multihash
+
+import (
+	"encoding/binary"
+)
+
+// This is a Keccak sponge (FIPS 202) with the padding domain byte and
+// the number of rounds as parameters, so that the SHA-3 and Keccak
+// hashes, the NIST SP 800-185 constructions (cSHAKE, KMAC, TupleHash,
+// ParallelHash) and KangarooTwelve are all built on top of it.
+
+var keccakRC = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotc = [24]uint{
+	1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44,
+}
+
+var keccakPiln = [24]int{
+	10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1,
+}
+
+// keccakP1600 applies the last rounds of the Keccak-f[1600]
+// permutation to a. With 24 rounds, it is Keccak-f[1600] itself.
+func keccakP1600(a *[25]uint64, rounds int) {
+	var bc [5]uint64
+	for r := 24 - rounds; r < 24; r++ {
+		// theta
+		for i := 0; i < 5; i++ {
+			bc[i] = a[i] ^ a[i+5] ^ a[i+10] ^ a[i+15] ^ a[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ rotl64(bc[(i+1)%5], 1)
+			for j := 0; j < 25; j += 5 {
+				a[j+i] ^= t
+			}
+		}
+
+		// rho and pi
+		t := a[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPiln[i]
+			bc[0] = a[j]
+			a[j] = rotl64(t, keccakRotc[i])
+			t = bc[0]
+		}
+
+		// chi
+		for j := 0; j < 25; j += 5 {
+			for i := 0; i < 5; i++ {
+				bc[i] = a[j+i]
+			}
+			for i := 0; i < 5; i++ {
+				a[j+i] ^= ^bc[(i+1)%5] & bc[(i+2)%5]
+			}
+		}
+
+		// iota
+		a[0] ^= keccakRC[r]
+	}
+}
+
+// sponge is a hash.Hash built on the Keccak sponge. Its digest is the
+// first size bytes squeezed out of the sponge.
+type sponge struct {
+	a      [25]uint64
+	buf    [200]byte
+	n      int
+	rate   int
+	ds     byte
+	size   int
+	rounds int
+
+	// state after absorbing any prefix, restored by Reset
+	init [25]uint64
+}
+
+// newKeccak returns a sponge with the given rate (in bytes), domain
+// separation byte and digest size.
+func newKeccak(rate int, ds byte, size int) *sponge {
+	return &sponge{rate: rate, ds: ds, size: size, rounds: 24}
+}
+
+// newCShake returns a cSHAKE sponge with the given rate (168 for
+// cSHAKE128, 136 for cSHAKE256), function name and customization
+// string. With both of those empty, cSHAKE is plain SHAKE.
+func newCShake(rate int, name, custom []byte, size int) *sponge {
+	if len(name) == 0 && len(custom) == 0 {
+		return newKeccak(rate, 0x1f, size)
+	}
+
+	k := newKeccak(rate, 0x04, size)
+	k.Write(bytepad(append(encodeString(name), encodeString(custom)...), rate))
+	k.init = k.a
+	return k
+}
+
+func (k *sponge) Reset() {
+	k.a = k.init
+	k.n = 0
+}
+
+func (k *sponge) Size() int      { return k.size }
+func (k *sponge) BlockSize() int { return k.rate }
+
+func (k *sponge) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		c := copy(k.buf[k.n:k.rate], p)
+		k.n += c
+		p = p[c:]
+		if k.n == k.rate {
+			k.absorb()
+		}
+	}
+	return n, nil
+}
+
+// absorb xors a full block into the state and permutes it.
+func (k *sponge) absorb() {
+	for i := 0; i < k.rate/8; i++ {
+		k.a[i] ^= binary.LittleEndian.Uint64(k.buf[i*8:])
+	}
+	keccakP1600(&k.a, k.rounds)
+	k.n = 0
+}
+
+func (k *sponge) Sum(in []byte) []byte {
+	d := *k
+	return append(in, d.squeeze(d.size)...)
+}
+
+// squeeze pads the input and reads n bytes from the sponge. It must
+// only be called once, on a copy of the hash.
+func (k *sponge) squeeze(n int) []byte {
+	for i := k.n; i < k.rate; i++ {
+		k.buf[i] = 0
+	}
+	k.buf[k.n] ^= k.ds
+	k.buf[k.rate-1] ^= 0x80
+	k.absorb()
+
+	out := make([]byte, 0, n+k.rate)
+	for {
+		for i := 0; i < k.rate/8; i++ {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], k.a[i])
+			out = append(out, b[:]...)
+		}
+		if len(out) >= n {
+			return out[:n]
+		}
+		keccakP1600(&k.a, k.rounds)
+	}
+}
+
+// leftEncode, rightEncode, encodeString and bytepad are the
+// encoding functions of NIST SP 800-185, section 2.3.
+func leftEncode(x uint64) []byte {
+	var b [9]byte
+	binary.BigEndian.PutUint64(b[1:], x)
+	i := 1
+	for i < 8 && b[i] == 0 {
+		i++
+	}
+	b[i-1] = byte(9 - i)
+	return b[i-1:]
+}
+
+func rightEncode(x uint64) []byte {
+	l := leftEncode(x)
+	return append(l[1:], l[0])
+}
+
+func encodeString(s []byte) []byte {
+	return append(leftEncode(uint64(len(s))*8), s...)
+}
+
+func bytepad(x []byte, w int) []byte {
+	b := append(leftEncode(uint64(w)), x...)
+	for len(b)%w != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
@@ -0,0 +1,200 @@
+package multihash
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+)
+
+// errors
+var (
+	ErrKeyRequired   = errors.New("multihash code requires a key")
+	ErrNotKeyed      = errors.New("multihash code does not take a key")
+	ErrInvalidParams = errors.New("invalid parameters for multihash code")
+)
+
+// Blake2Params holds the optional parameters of keyed BLAKE2
+// hashes. Salt and Personal are zero-padded to their full size.
+type Blake2Params struct {
+	Key      []byte // up to 64 bytes for blake2b, 32 for blake2s
+	Salt     []byte // up to 16 bytes for blake2b, 8 for blake2s
+	Personal []byte // up to 16 bytes for blake2b, 8 for blake2s
+}
+
+// NewKeyedHasher returns a Hasher computing a message authentication
+// code with the given key. The code must be one of a keyed hash
+// function, such as HMAC_SHA2_256, KMAC_128 or a KEYED_BLAKE2B code.
+func NewKeyedHasher(code uint64, length int, key []byte) (Hasher, error) {
+	f, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.newKeyedHash == nil {
+		return nil, codeError(ErrNotKeyed, code)
+	}
+
+	h, err := f.newKeyedHash(key, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(code, length, h)
+}
+
+// SumKeyed is like Sum but computes a message authentication code of
+// the data with the given key. See NewKeyedHasher.
+func SumKeyed(data, key []byte, code uint64, length int) (Multihash, error) {
+	h, err := NewKeyedHasher(code, length, key)
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(data)
+	return h.Multihash()
+}
+
+// NewBlake2Hasher returns a Hasher for a keyed BLAKE2 code which also
+// uses a salt and a personalization string.
+func NewBlake2Hasher(code uint64, length int, p Blake2Params) (Hasher, error) {
+	if !isKeyedBlake2b(code) && !isKeyedBlake2s(code) {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	_, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := newKeyedBlake2(code, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(code, length, h)
+}
+
+// NewKMACHasher returns a Hasher for KMAC_128 or KMAC_256 which also
+// uses a customization string. Unlike other MACs, the length is part
+// of the KMAC computation: KMAC digests of different lengths are
+// unrelated.
+func NewKMACHasher(code uint64, length int, key, custom []byte) (Hasher, error) {
+	if code != KMAC_128 && code != KMAC_256 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	_, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkXOFLength(code, length); err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(code, length, newKMAC(code, key, custom, length))
+}
+
+// builtinKeyedHashFunc returns the KeyedHashFunc for a built-in
+// keyed code, or nil if the code is not keyed.
+func builtinKeyedHashFunc(code uint64) KeyedHashFunc {
+	switch {
+	case isKeyedBlake2b(code), isKeyedBlake2s(code):
+		return func(key []byte, length int) (hash.Hash, error) {
+			return newKeyedBlake2(code, Blake2Params{Key: key})
+		}
+	}
+
+	switch code {
+	case HMAC_SHA1:
+		return hmacHashFunc(sha1.New)
+	case HMAC_SHA2_256:
+		return hmacHashFunc(sha256.New)
+	case HMAC_SHA2_512:
+		return hmacHashFunc(sha512.New)
+	case KMAC_128, KMAC_256:
+		return func(key []byte, length int) (hash.Hash, error) {
+			if err := checkXOFLength(code, length); err != nil {
+				return nil, err
+			}
+			return newKMAC(code, key, nil, length), nil
+		}
+	case KEYED_BLAKE3:
+		return func(key []byte, length int) (hash.Hash, error) {
+			if len(key) != 32 {
+				return nil, codeError(ErrInvalidParams, code)
+			}
+			if err := checkXOFLength(code, length); err != nil {
+				return nil, err
+			}
+			return newKeyedBlake3(key, length), nil
+		}
+	case BLAKE3_DERIVE_KEY:
+		return func(context []byte, length int) (hash.Hash, error) {
+			if err := checkXOFLength(code, length); err != nil {
+				return nil, err
+			}
+			return newBlake3DeriveKey(context, length), nil
+		}
+	}
+	return nil
+}
+
+func hmacHashFunc(f func() hash.Hash) KeyedHashFunc {
+	return func(key []byte, length int) (hash.Hash, error) {
+		return hmac.New(f, key), nil
+	}
+}
+
+func isKeyedBlake2b(code uint64) bool {
+	return code >= KEYED_BLAKE2B_MIN && code <= KEYED_BLAKE2B_MAX
+}
+
+func isKeyedBlake2s(code uint64) bool {
+	return code >= KEYED_BLAKE2S_MIN && code <= KEYED_BLAKE2S_MAX
+}
+
+func newKeyedBlake2(code uint64, p Blake2Params) (hash.Hash, error) {
+	// without a key, the digest is the unkeyed BLAKE2 one
+	if len(p.Key) == 0 {
+		return nil, codeError(ErrKeyRequired, code)
+	}
+
+	if isKeyedBlake2b(code) {
+		if len(p.Key) > 64 || len(p.Salt) > 16 || len(p.Personal) > 16 {
+			return nil, codeError(ErrInvalidParams, code)
+		}
+		return newBlake2b(int(code-KEYED_BLAKE2B_MIN+1), p.Key, p.Salt, p.Personal), nil
+	}
+
+	if len(p.Key) > 32 || len(p.Salt) > 8 || len(p.Personal) > 8 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+	return newBlake2s(int(code-KEYED_BLAKE2S_MIN+1), p.Key, p.Salt, p.Personal), nil
+}
+
+// kmac computes KMAC128 or KMAC256 (NIST SP 800-185).
+type kmac struct {
+	*sponge
+}
+
+func newKMAC(code uint64, key, custom []byte, length int) hash.Hash {
+	rate := 168
+	if code == KMAC_256 {
+		rate = 136
+	}
+
+	k := newCShake(rate, []byte("KMAC"), custom, length)
+	k.Write(bytepad(encodeString(key), rate))
+	k.init = k.a
+	return &kmac{k}
+}
+
+func (k *kmac) Sum(in []byte) []byte {
+	d := *k.sponge
+	d.Write(rightEncode(uint64(d.size) * 8))
+	return append(in, d.squeeze(d.size)...)
+}
@@ -0,0 +1,152 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// 0x40, 0x41, ..., 0x5f
+var macKey = []byte("@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_")
+
+type MacTestCase struct {
+	code   uint64
+	length int
+	key    []byte
+	input  []byte
+	hex    string
+}
+
+var macTestCases = []MacTestCase{
+	// RFC 2104 style, from the Wikipedia HMAC article
+	MacTestCase{HMAC_SHA1, -1, []byte("key"), []byte("The quick brown fox jumps over the lazy dog"), "de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9"},
+	MacTestCase{HMAC_SHA2_256, -1, []byte("key"), []byte("The quick brown fox jumps over the lazy dog"), "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+	MacTestCase{HMAC_SHA2_256, 16, []byte("key"), []byte("The quick brown fox jumps over the lazy dog"), "f7bc83f430538424b13298e6aa6fb143"},
+	MacTestCase{HMAC_SHA2_512, -1, []byte("key"), []byte("The quick brown fox jumps over the lazy dog"), "b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a"},
+
+	// NIST SP 800-185 samples
+	MacTestCase{KMAC_128, 32, macKey, []byte{0, 1, 2, 3}, "e5780b0d3ea6f7d3a429c5706aa43a00fadbd7d49628839e3187243f456ee14e"},
+
+	// keyed blake2
+	MacTestCase{KEYED_BLAKE2B_MIN + 31, -1, macKey, []byte("foo"), "9feeab32dd928e31ae20548d9505dc8d0b724b52e71f80ae674967a2ff535d74"},
+	MacTestCase{KEYED_BLAKE2B_MAX, -1, macKey, []byte{}, "6266aa2dae9aeac682aee06e19d53fe471663d64ca27c18db7805ce779b3a6337d1e71b2e01cba31c00ea3af4dbefa4ece1f838d69c3d0f2549563df9a215332"},
+	MacTestCase{KEYED_BLAKE2B_MAX, -1, macKey, patternInput(300), "329b84f51f36cb9d520406cace1f651a97c63031da3573385afd318fc4009e65d2e85fa02b2f388380f81883fd75a39542d64c643715d1e4f6c97587ae46dfe1"},
+	MacTestCase{KEYED_BLAKE2S_MAX, -1, macKey, []byte("foo"), "6a6abfd7ad80af2a8df8482c35eced696b7e3cb4cd56be98ccf1912fe5069de5"},
+}
+
+func TestSumKeyed(t *testing.T) {
+	for _, tc := range macTestCases {
+		m, err := SumKeyed(tc.input, tc.key, tc.code, tc.length)
+		if err != nil {
+			t.Error(Codes[tc.code], err)
+			continue
+		}
+
+		dm, err := Decode(m)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if dm.Code != tc.code || dm.Name != Codes[tc.code] {
+			t.Error("decoded code mismatch: ", dm.Code, dm.Name)
+		}
+
+		if hex.EncodeToString(dm.Digest) != tc.hex {
+			t.Error(dm.Name, "wrong mac", hex.EncodeToString(dm.Digest))
+		}
+
+		// streaming, and after a reset
+		h, err := NewKeyedHasher(tc.code, tc.length, tc.key)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		h.Write([]byte("garbage"))
+		h.Reset()
+		for _, b := range tc.input {
+			h.Write([]byte{b})
+		}
+		m2, err := h.Multihash()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !bytes.Equal(m, m2) {
+			t.Error(dm.Name, "streamed mac mismatch")
+		}
+	}
+}
+
+func TestKMACCustomization(t *testing.T) {
+	cases := []struct {
+		code   uint64
+		length int
+		hex    string
+	}{
+		// NIST SP 800-185 samples #2 and #4
+		{KMAC_128, 32, "3b1fba963cd8b0b59e8c1a6d71888b7143651af8ba0a7070c0979e2811324aa5"},
+		{KMAC_256, 64, "20c570c31346f703c9ac36c61c03cb64c3970d0cfc787e9b79599d273a68d2f7f69d4cc3de9d104a351689f27cf6f5951f0103f33f4f24871024d9c27773a8dd"},
+	}
+
+	for _, tc := range cases {
+		h, err := NewKMACHasher(tc.code, tc.length, macKey, []byte("My Tagged Application"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte{0, 1, 2, 3})
+		if d := hex.EncodeToString(h.Sum(nil)); d != tc.hex {
+			t.Error(Codes[tc.code], "wrong mac", d)
+		}
+	}
+}
+
+func TestBlake2Params(t *testing.T) {
+	cases := []struct {
+		code uint64
+		p    Blake2Params
+		hex  string
+	}{
+		{KEYED_BLAKE2B_MIN + 19, Blake2Params{Key: macKey, Salt: []byte("saltsalt"), Personal: []byte("my app")}, "aa88effe6d9702555a40da36a16a4581fc7ec917"},
+		{KEYED_BLAKE2S_MIN + 15, Blake2Params{Key: macKey[:16], Salt: []byte("salt"), Personal: []byte("app")}, "525053359310a694c31e6245eb996ea5"},
+	}
+
+	for _, tc := range cases {
+		h, err := NewBlake2Hasher(tc.code, -1, tc.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte("foo"))
+		if d := hex.EncodeToString(h.Sum(nil)); d != tc.hex {
+			t.Error(Codes[tc.code], "wrong digest", d)
+		}
+	}
+
+	if _, err := NewBlake2Hasher(KEYED_BLAKE2S_MAX, -1, Blake2Params{Salt: make([]byte, 9)}); err == nil {
+		t.Error("blake2s salt longer than 8 bytes should fail")
+	}
+	if _, err := NewBlake2Hasher(BLAKE2B_MAX, -1, Blake2Params{}); err == nil {
+		t.Error("unkeyed blake2 code should fail")
+	}
+}
+
+func TestKeyedCodeErrors(t *testing.T) {
+	if _, err := NewHasher(HMAC_SHA2_256, -1); !errors.Is(err, ErrKeyRequired) {
+		t.Error("expected ErrKeyRequired, got: ", err)
+	}
+	if _, err := Sum([]byte("foo"), KEYED_BLAKE2B_MAX, -1); !errors.Is(err, ErrKeyRequired) {
+		t.Error("expected ErrKeyRequired, got: ", err)
+	}
+	for _, key := range [][]byte{nil, {}} {
+		if _, err := SumKeyed([]byte("foo"), key, KEYED_BLAKE2B_MIN+31, -1); !errors.Is(err, ErrKeyRequired) {
+			t.Errorf("%q: expected ErrKeyRequired, got: %v", key, err)
+		}
+		if _, err := NewBlake2Hasher(KEYED_BLAKE2S_MAX, -1, Blake2Params{Key: key, Salt: []byte("salt")}); !errors.Is(err, ErrKeyRequired) {
+			t.Errorf("%q: expected ErrKeyRequired, got: %v", key, err)
+		}
+	}
+	if _, err := SumKeyed([]byte("foo"), macKey, SHA2_256, -1); !errors.Is(err, ErrNotKeyed) {
+		t.Error("expected ErrNotKeyed, got: ", err)
+	}
+}
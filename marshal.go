@@ -0,0 +1,165 @@
+package multihash
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalText encodes m in the legacy base58 form of B58String. An
+// empty multihash is encoded as an empty string. Use a MultihashText
+// for other multibases.
+func (m Multihash) MarshalText() ([]byte, error) {
+	return m.marshalText(LegacyBase58)
+}
+
+func (m Multihash) marshalText(base Multibase) ([]byte, error) {
+	if len(m) == 0 {
+		return []byte{}, nil
+	}
+
+	s, err := m.ToString(base)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText parses a multihash with FromString. An empty string
+// gives an empty multihash.
+func (m *Multihash) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = Multihash{}
+		return nil
+	}
+
+	m2, err := FromString(string(text))
+	if err != nil {
+		return err
+	}
+	*m = m2
+	return nil
+}
+
+// MarshalJSON encodes m as a JSON string of its text form, or as null
+// if it is nil.
+func (m Multihash) MarshalJSON() ([]byte, error) {
+	return m.marshalJSON(LegacyBase58)
+}
+
+func (m Multihash) marshalJSON(base Multibase) ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	text, err := m.marshalText(base)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses a JSON string with UnmarshalText. Like the
+// encoding/json package, it leaves m unchanged for null.
+func (m *Multihash) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(s))
+}
+
+// MultihashText is a Multihash which is encoded in text and JSON with
+// the multibase Base, such as Base32, instead of the legacy base58 form
+// of Multihash. The zero Base is LegacyBase58. Unmarshalling accepts
+// all the forms FromString does, and leaves Base unchanged. In SQL
+// databases, it is stored in the same text form (see Value).
+type MultihashText struct {
+	Multihash
+	Base Multibase
+}
+
+// MarshalText encodes t.Multihash with t.Base. An empty multihash is
+// encoded as an empty string.
+func (t MultihashText) MarshalText() ([]byte, error) {
+	return t.Multihash.marshalText(t.Base)
+}
+
+// MarshalJSON encodes t.Multihash as a JSON string of its text form,
+// or as null if it is nil.
+func (t MultihashText) MarshalJSON() ([]byte, error) {
+	return t.Multihash.marshalJSON(t.Base)
+}
+
+// MarshalBinary returns a copy of the bytes of m.
+func (m Multihash) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, m...), nil
+}
+
+// UnmarshalBinary checks data with Cast, and sets m to a copy of it.
+func (m *Multihash) UnmarshalBinary(data []byte) error {
+	if _, err := Cast(data); err != nil {
+		return err
+	}
+	*m = append(Multihash{}, data...)
+	return nil
+}
+
+// decodedMultihashJSON is the JSON form of a DecodedMultihash.
+type decodedMultihashJSON struct {
+	Name   string `json:"name,omitempty"`
+	Code   uint64 `json:"code"`
+	Length int    `json:"length"`
+	Digest string `json:"digest"`
+}
+
+// MarshalJSON encodes dm as a JSON object with its name, code, length
+// and hexadecimal digest, such as
+//
+//	{"name":"sha1","code":17,"length":20,"digest":"0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33"}
+//
+// The name is omitted for codes unknown to this package.
+func (dm DecodedMultihash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(decodedMultihashJSON{
+		Name:   dm.Name,
+		Code:   dm.Code,
+		Length: dm.Length,
+		Digest: hex.EncodeToString(dm.Digest),
+	})
+}
+
+// UnmarshalJSON parses the JSON form of MarshalJSON. It checks that
+// the length is that of the digest, and that the name, if present, is
+// the one of the code.
+func (dm *DecodedMultihash) UnmarshalJSON(data []byte) error {
+	var j decodedMultihashJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	digest, err := hex.DecodeString(j.Digest)
+	if err != nil {
+		return err
+	}
+	return dm.set(j.Name, j.Code, j.Length, digest)
+}
+
+// set checks the fields of a marshalled DecodedMultihash, and sets dm
+// to them. The name may be empty, and is then looked up from the code.
+func (dm *DecodedMultihash) set(name string, code uint64, length int, digest []byte) error {
+	if len(digest) != length {
+		return &Error{Err: ErrInconsistentLen{}, Code: code, Length: length, Actual: len(digest), Offset: -1}
+	}
+
+	known, _ := LookupName(code)
+	if name != "" && name != known {
+		return fmt.Errorf("multihash name %q is not the one of code 0x%x: %w", name, code, ErrUnknownCode)
+	}
+
+	*dm = DecodedMultihash{Code: code, Name: known, Length: length, Digest: digest}
+	return nil
+}
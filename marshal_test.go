@@ -0,0 +1,189 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type marshalTest struct {
+	Hash  Multihash  `json:"hash"`
+	Other *Multihash `json:"other"`
+}
+
+func TestMarshalJSON(t *testing.T) {
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+
+	b, err := json.Marshal(marshalTest{Hash: m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"hash":"QmRJzsvyCQyizr73Gmms8ZRtvNxmgqumxc2KUp71dfEmoj","other":null}`
+	if string(b) != want {
+		t.Errorf("got %s, expected %s", b, want)
+	}
+
+	var v marshalTest
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v.Hash, m) || v.Other != nil {
+		t.Errorf("decoded %+v", v)
+	}
+
+	// any multibase is accepted
+	if err := json.Unmarshal([]byte(`{"hash":"f12202c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v.Hash, m) {
+		t.Errorf("decoded %x", v.Hash)
+	}
+
+	for _, s := range []string{`{"hash":"QmRJzsvyCQyizr73Gmms8ZRtvNx"}`, `{"hash":12}`, `{"hash":"f1220"}`} {
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+}
+
+func TestMultihashText(t *testing.T) {
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+	for base, want := range map[Multibase]string{
+		LegacyBase58: "QmRJzsvyCQyizr73Gmms8ZRtvNxmgqumxc2KUp71dfEmoj",
+		Base32:       "bciqcyjvunnup7rup7gnukpa5gbatie2cfvygja57ud4yuxuimjtoplq",
+		Base16:       "f12202c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+	} {
+		text, err := MultihashText{m, base}.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != want {
+			t.Errorf("%c: got %s, expected %s", base, text, want)
+		}
+
+		mt := MultihashText{Base: base}
+		if err := mt.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(m, mt.Multihash) || mt.Base != base {
+			t.Errorf("%c: decoded %+v", base, mt)
+		}
+	}
+
+	// the base is per value, and Multihash keeps the legacy form
+	v := struct {
+		Hash  Multihash     `json:"hash"`
+		Text  MultihashText `json:"text"`
+		Other MultihashText `json:"other"`
+	}{m, MultihashText{m, Base32}, MultihashText{Base: Base32}}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"hash":"QmRJzsvyCQyizr73Gmms8ZRtvNxmgqumxc2KUp71dfEmoj","text":"bciqcyjvunnup7rup7gnukpa5gbatie2cfvygja57ud4yuxuimjtoplq","other":null}`
+	if string(b) != want {
+		t.Errorf("got %s, expected %s", b, want)
+	}
+
+	v.Text = MultihashText{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v.Text.Multihash, m) || v.Other.Multihash != nil {
+		t.Errorf("decoded %+v", v)
+	}
+
+	if _, err := (MultihashText{m, '?'}).MarshalText(); err != ErrUnknownMultibase {
+		t.Error("expected ErrUnknownMultibase, got: ", err)
+	}
+	if _, err := json.Marshal(MultihashText{m, '?'}); !errors.Is(err, ErrUnknownMultibase) {
+		t.Error("expected ErrUnknownMultibase, got: ", err)
+	}
+}
+
+func TestMarshalTextEmpty(t *testing.T) {
+	text, err := Multihash{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("got %q, %v", text, err)
+	}
+
+	m := Multihash{1, 2, 3}
+	if err := m.UnmarshalText(nil); err != nil || len(m) != 0 {
+		t.Errorf("got %x, %v", m, err)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, m) {
+		t.Errorf("got %x", b)
+	}
+	b[2]++
+	if bytes.Equal(b, m) {
+		t.Error("MarshalBinary did not copy")
+	}
+
+	var m2 Multihash
+	if err := m2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, m2) {
+		t.Errorf("decoded %x", m2)
+	}
+
+	if err := m2.UnmarshalBinary(b[:10]); !errors.Is(err, ErrInconsistentLen{}) {
+		t.Error("expected ErrInconsistentLen, got: ", err)
+	}
+}
+
+func TestDecodedMultihashJSON(t *testing.T) {
+	m, _ := Sum([]byte("foo"), SHA1, -1)
+	dm, _ := Decode(m)
+
+	b, err := json.Marshal(dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"sha1","code":17,"length":20,"digest":"0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33"}`
+	if string(b) != want {
+		t.Errorf("got %s, expected %s", b, want)
+	}
+
+	var dm2 DecodedMultihash
+	if err := json.Unmarshal(b, &dm2); err != nil {
+		t.Fatal(err)
+	}
+	if dm2.Code != dm.Code || dm2.Name != dm.Name || dm2.Length != dm.Length || !bytes.Equal(dm2.Digest, dm.Digest) {
+		t.Errorf("decoded %+v", dm2)
+	}
+
+	// the name is optional
+	if err := json.Unmarshal([]byte(`{"code":17,"length":1,"digest":"0b"}`), &dm2); err != nil {
+		t.Fatal(err)
+	}
+	if dm2.Name != "sha1" {
+		t.Errorf("decoded %+v", dm2)
+	}
+
+	cases := []struct {
+		s   string
+		err error
+	}{
+		{`{"name":"sha1","code":17,"length":2,"digest":"0b"}`, ErrInconsistentLen{}},
+		{`{"name":"md5","code":17,"length":1,"digest":"0b"}`, ErrUnknownCode},
+	}
+	for _, tc := range cases {
+		if err := json.Unmarshal([]byte(tc.s), &dm2); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.s, tc.err, err)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"code":17,"length":1,"digest":"zz"}`), &dm2); err == nil {
+		t.Error("expected an error for an invalid digest")
+	}
+}
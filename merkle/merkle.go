@@ -0,0 +1,324 @@
+// Package merkle builds binary Merkle trees over chunks of data with
+// any multihash function, following the algorithms of RFC 6962 (and
+// RFC 9162): it computes their roots, and generates and verifies
+// inclusion and consistency proofs.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	mh "github.com/multiformats/go-multihash"
+)
+
+// package errors
+var (
+	ErrInvalidProof = errors.New("merkle: invalid proof")
+	ErrOutOfRange   = errors.New("merkle: index or size out of range")
+)
+
+// Scheme is the domain separation of a tree: the prefixes hashed
+// before the data of a leaf, and before the two children of a node.
+// They must differ, or a node could be passed off as a leaf.
+type Scheme struct {
+	LeafPrefix []byte
+	NodePrefix []byte
+}
+
+// RFC6962 is the scheme of Certificate Transparency.
+var RFC6962 = Scheme{LeafPrefix: []byte{0x00}, NodePrefix: []byte{0x01}}
+
+// Hasher computes the hashes of the trees of a hash function and
+// scheme. It is safe for concurrent use.
+type Hasher struct {
+	code   uint64
+	length int
+	scheme Scheme
+}
+
+// NewHasher returns a Hasher for the given multihash code, digest
+// length (negative for the default) and scheme. Every hash of the
+// tree is truncated to length.
+func NewHasher(code uint64, length int, scheme Scheme) (*Hasher, error) {
+	if code == mh.ID {
+		return nil, fmt.Errorf("merkle: the identity hash cannot be used: %w", mh.ErrInvalidParams)
+	}
+
+	h, err := mh.NewHasher(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hasher{code: code, length: h.Size(), scheme: scheme}, nil
+}
+
+func (h *Hasher) sum(prefix []byte, data ...[]byte) []byte {
+	// the code and length were checked by NewHasher
+	s, _ := mh.NewHasher(h.code, h.length)
+	s.Write(prefix)
+	for _, d := range data {
+		s.Write(d)
+	}
+	return s.Sum(nil)
+}
+
+// HashLeaf returns the hash of a leaf with the given data.
+func (h *Hasher) HashLeaf(data []byte) []byte {
+	return h.sum(h.scheme.LeafPrefix, data)
+}
+
+// HashChildren returns the hash of a node with the given children
+// hashes.
+func (h *Hasher) HashChildren(left, right []byte) []byte {
+	return h.sum(h.scheme.NodePrefix, left, right)
+}
+
+// EmptyRoot returns the root hash of an empty tree, which is the hash
+// of no data.
+func (h *Hasher) EmptyRoot() []byte {
+	return h.sum(nil)
+}
+
+// Root returns the root of the tree whose leaves are chunks.
+func (h *Hasher) Root(chunks [][]byte) mh.Multihash {
+	t := NewTree(h)
+	for _, c := range chunks {
+		t.Append(c)
+	}
+	return t.Root()
+}
+
+func (h *Hasher) multihash(digest []byte) mh.Multihash {
+	// the code was checked by NewHasher
+	m, _ := mh.Encode(digest, h.code)
+	return m
+}
+
+// rootDigest returns the digest of root, which must have the code and
+// length of h.
+func (h *Hasher) rootDigest(root mh.Multihash) ([]byte, error) {
+	dm, err := mh.Decode(root)
+	if err != nil {
+		return nil, err
+	}
+	if dm.Code != h.code || dm.Length != h.length {
+		name, _ := mh.LookupName(h.code)
+		return nil, fmt.Errorf("merkle: root is not a %d byte %s multihash: %w", h.length, name, ErrInvalidProof)
+	}
+	return dm.Digest, nil
+}
+
+// Tree is a Merkle tree which leaves are appended to. It keeps the
+// hashes of the leaves, so it can compute the root and the proofs of
+// any of its previous sizes.
+type Tree struct {
+	h      *Hasher
+	leaves [][]byte
+}
+
+// NewTree returns an empty tree.
+func NewTree(h *Hasher) *Tree {
+	return &Tree{h: h}
+}
+
+// Append adds a leaf with the given data to the tree.
+func (t *Tree) Append(data []byte) {
+	t.leaves = append(t.leaves, t.h.HashLeaf(data))
+}
+
+// Size returns the number of leaves of the tree.
+func (t *Tree) Size() int {
+	return len(t.leaves)
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() mh.Multihash {
+	return t.h.multihash(t.mth(t.leaves))
+}
+
+// RootAt returns the root the tree had when it had size leaves.
+func (t *Tree) RootAt(size int) (mh.Multihash, error) {
+	if size < 0 || size > len(t.leaves) {
+		return nil, ErrOutOfRange
+	}
+	return t.h.multihash(t.mth(t.leaves[:size])), nil
+}
+
+// split returns the size of the left subtree of a tree of n > 1
+// leaves: the largest power of two smaller than n.
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// mth is the Merkle tree hash of the given leaf hashes.
+func (t *Tree) mth(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		return t.h.EmptyRoot()
+	case 1:
+		return leaves[0]
+	}
+
+	k := split(len(leaves))
+	return t.h.HashChildren(t.mth(leaves[:k]), t.mth(leaves[k:]))
+}
+
+// InclusionProof returns the proof that the leaf at index is in the
+// tree of the given size.
+func (t *Tree) InclusionProof(index, size int) ([][]byte, error) {
+	if size > len(t.leaves) || index < 0 || index >= size {
+		return nil, ErrOutOfRange
+	}
+	return t.path(index, t.leaves[:size]), nil
+}
+
+func (t *Tree) path(m int, leaves [][]byte) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+
+	k := split(len(leaves))
+	if m < k {
+		return append(t.path(m, leaves[:k]), t.mth(leaves[k:]))
+	}
+	return append(t.path(m-k, leaves[k:]), t.mth(leaves[:k]))
+}
+
+// ConsistencyProof returns the proof that the tree of oldSize leaves
+// is a prefix of the tree of newSize leaves.
+func (t *Tree) ConsistencyProof(oldSize, newSize int) ([][]byte, error) {
+	if newSize > len(t.leaves) || oldSize < 0 || oldSize > newSize {
+		return nil, ErrOutOfRange
+	}
+	if oldSize == 0 {
+		return nil, nil
+	}
+	return t.subproof(oldSize, t.leaves[:newSize], true), nil
+}
+
+func (t *Tree) subproof(m int, leaves [][]byte, complete bool) [][]byte {
+	n := len(leaves)
+	if m == n {
+		if complete {
+			return nil
+		}
+		return [][]byte{t.mth(leaves)}
+	}
+
+	k := split(n)
+	if m <= k {
+		return append(t.subproof(m, leaves[:k], complete), t.mth(leaves[k:]))
+	}
+	return append(t.subproof(m-k, leaves[k:], false), t.mth(leaves[:k]))
+}
+
+// VerifyInclusion checks that proof proves that a leaf with the given
+// data is at index in the tree of the given size and root.
+func (h *Hasher) VerifyInclusion(root mh.Multihash, index, size int, data []byte, proof [][]byte) error {
+	want, err := h.rootDigest(root)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= size {
+		return ErrOutOfRange
+	}
+
+	fn, sn := index, size-1
+	r := h.HashLeaf(data)
+	for _, p := range proof {
+		if sn == 0 {
+			return ErrInvalidProof
+		}
+
+		if fn&1 == 1 || fn == sn {
+			r = h.HashChildren(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = h.HashChildren(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 || !bytes.Equal(r, want) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// VerifyConsistency checks that proof proves that the tree of oldSize
+// leaves and root oldRoot is a prefix of the tree of newSize leaves
+// and root newRoot.
+func (h *Hasher) VerifyConsistency(oldRoot, newRoot mh.Multihash, oldSize, newSize int, proof [][]byte) error {
+	first, err := h.rootDigest(oldRoot)
+	if err != nil {
+		return err
+	}
+	second, err := h.rootDigest(newRoot)
+	if err != nil {
+		return err
+	}
+	if oldSize < 0 || oldSize > newSize {
+		return ErrOutOfRange
+	}
+
+	switch {
+	case oldSize == newSize:
+		if len(proof) != 0 || !bytes.Equal(first, second) {
+			return ErrInvalidProof
+		}
+		return nil
+	case oldSize == 0:
+		// every tree extends the empty tree
+		if len(proof) != 0 {
+			return ErrInvalidProof
+		}
+		return nil
+	case len(proof) == 0:
+		return ErrInvalidProof
+	}
+
+	// the old tree is a complete subtree: its root is implied
+	if oldSize&(oldSize-1) == 0 {
+		proof = append([][]byte{first}, proof...)
+	}
+
+	fn, sn := oldSize-1, newSize-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+
+	fr, sr := proof[0], proof[0]
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return ErrInvalidProof
+		}
+
+		if fn&1 == 1 || fn == sn {
+			fr = h.HashChildren(c, fr)
+			sr = h.HashChildren(c, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = h.HashChildren(sr, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 || !bytes.Equal(fr, first) || !bytes.Equal(sr, second) {
+		return ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package merkle
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	mh "github.com/multiformats/go-multihash"
+)
+
+// The test vectors of Certificate Transparency.
+
+var ctLeaves = []string{"", "00", "10", "2021", "3031", "40414243", "5051525354555657", "606162636465666768696a6b6c6d6e6f"}
+
+var ctRoots = []string{
+	"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+	"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+	"aeb6bcfe274b70a14fb067a5e5578264db0fa9b51af5e0ba159158f329e06e77",
+	"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	"4e3bbb1f7b478dcfe71fb631631519a3bca12c9aefca1612bfce4c13a86264d4",
+	"76e67dadbcdf1e10e1b74ddc608abd2f98dfb16fbce75277b5232a127f2087ef",
+	"ddb89be403809e325750d3d263cd78929c2942b7942a34b77e122c9594a74c8c",
+	"5dc9da79a70659a9ad559cb701ded9a2ab9d823aad2f4960cfe370eff4604328",
+}
+
+var ctInclusionProofs = []struct {
+	index, size int
+	proof       []string
+}{
+	{0, 1, nil},
+	{0, 8, []string{
+		"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+		"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+		"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4",
+	}},
+	{5, 8, []string{
+		"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+		"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+		"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	}},
+	{2, 3, []string{
+		"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+	}},
+	{1, 5, []string{
+		"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+		"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+		"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+	}},
+}
+
+var ctConsistencyProofs = []struct {
+	oldSize, newSize int
+	proof            []string
+}{
+	{1, 1, nil},
+	{1, 8, []string{
+		"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+		"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+		"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4",
+	}},
+	{6, 8, []string{
+		"0ebc5d3437fbe2db158b9f126a1d118e308181031d0a949f8dededebc558ef6a",
+		"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+		"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	}},
+	{2, 5, []string{
+		"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+		"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+	}},
+	{6, 7, []string{
+		"0ebc5d3437fbe2db158b9f126a1d118e308181031d0a949f8dededebc558ef6a",
+		"b08693ec2e721597130641e8211e7eedccb4c26413963eee6c1e2ed16ffb1a5f",
+		"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	}},
+}
+
+func decodeHex(t *testing.T, hexes []string) [][]byte {
+	t.Helper()
+
+	var out [][]byte
+	for _, s := range hexes {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+func newCTTree(t *testing.T) (*Hasher, *Tree) {
+	t.Helper()
+
+	h, err := NewHasher(mh.SHA2_256, -1, RFC6962)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree := NewTree(h)
+	for _, l := range decodeHex(t, ctLeaves) {
+		tree.Append(l)
+	}
+	return h, tree
+}
+
+func proofString(proof [][]byte) string {
+	var s string
+	for _, p := range proof {
+		s += hex.EncodeToString(p) + " "
+	}
+	return s
+}
+
+func TestRoots(t *testing.T) {
+	h, tree := newCTTree(t)
+
+	for size, want := range ctRoots {
+		root, err := tree.RootAt(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dm, _ := mh.Decode(root)
+		if dm.Code != mh.SHA2_256 || hex.EncodeToString(dm.Digest) != want {
+			t.Errorf("size %d: wrong root %s", size, root.HexString())
+		}
+	}
+
+	root := h.Root(decodeHex(t, ctLeaves))
+	if !bytesEqual(root, tree.Root()) {
+		t.Error("Hasher.Root differs from Tree.Root")
+	}
+}
+
+func bytesEqual(a, b mh.Multihash) bool {
+	return a.HexString() == b.HexString()
+}
+
+func TestInclusionProofs(t *testing.T) {
+	h, tree := newCTTree(t)
+	leaves := decodeHex(t, ctLeaves)
+
+	for _, tc := range ctInclusionProofs {
+		proof, err := tree.InclusionProof(tc.index, tc.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := proofString(proof), proofString(decodeHex(t, tc.proof)); got != want {
+			t.Errorf("%d in %d: got proof %s", tc.index, tc.size, got)
+		}
+
+		root, _ := tree.RootAt(tc.size)
+		if err := h.VerifyInclusion(root, tc.index, tc.size, leaves[tc.index], proof); err != nil {
+			t.Errorf("%d in %d: %s", tc.index, tc.size, err)
+		}
+	}
+}
+
+func TestConsistencyProofs(t *testing.T) {
+	h, tree := newCTTree(t)
+
+	for _, tc := range ctConsistencyProofs {
+		proof, err := tree.ConsistencyProof(tc.oldSize, tc.newSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := proofString(proof), proofString(decodeHex(t, tc.proof)); got != want {
+			t.Errorf("%d to %d: got proof %s", tc.oldSize, tc.newSize, got)
+		}
+
+		oldRoot, _ := tree.RootAt(tc.oldSize)
+		newRoot, _ := tree.RootAt(tc.newSize)
+		if err := h.VerifyConsistency(oldRoot, newRoot, tc.oldSize, tc.newSize, proof); err != nil {
+			t.Errorf("%d to %d: %s", tc.oldSize, tc.newSize, err)
+		}
+	}
+}
+
+// TestAllProofs checks every proof of small trees, and that altering
+// them fails, with another hash function and scheme.
+func TestAllProofs(t *testing.T) {
+	h, err := NewHasher(mh.BLAKE3, 20, Scheme{LeafPrefix: []byte("leaf"), NodePrefix: []byte("node")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree := NewTree(h)
+	var leaves [][]byte
+	for i := 0; i < 20; i++ {
+		leaves = append(leaves, []byte{byte(i)})
+		tree.Append(leaves[i])
+	}
+
+	for size := 1; size <= tree.Size(); size++ {
+		root, _ := tree.RootAt(size)
+
+		for i := 0; i < size; i++ {
+			proof, err := tree.InclusionProof(i, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.VerifyInclusion(root, i, size, leaves[i], proof); err != nil {
+				t.Errorf("%d in %d: %s", i, size, err)
+			}
+			if err := h.VerifyInclusion(root, i, size, []byte("other"), proof); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("%d in %d: wrong leaf verified", i, size)
+			}
+			if size > 1 {
+				if err := h.VerifyInclusion(root, (i+1)%size, size, leaves[i], proof); !errors.Is(err, ErrInvalidProof) {
+					t.Errorf("%d in %d: wrong index verified", i, size)
+				}
+			}
+		}
+
+		for old := 1; old < size; old++ {
+			oldRoot, _ := tree.RootAt(old)
+			proof, err := tree.ConsistencyProof(old, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.VerifyConsistency(oldRoot, root, old, size, proof); err != nil {
+				t.Errorf("%d to %d: %s", old, size, err)
+			}
+
+			bad := append([][]byte{}, proof...)
+			bad[len(bad)-1] = h.HashLeaf(nil)
+			if err := h.VerifyConsistency(oldRoot, root, old, size, bad); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("%d to %d: altered proof verified", old, size)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := NewHasher(mh.ID, -1, RFC6962); !errors.Is(err, mh.ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+
+	h, tree := newCTTree(t)
+	if _, err := tree.InclusionProof(8, 8); !errors.Is(err, ErrOutOfRange) {
+		t.Error("expected ErrOutOfRange, got: ", err)
+	}
+	if _, err := tree.ConsistencyProof(3, 9); !errors.Is(err, ErrOutOfRange) {
+		t.Error("expected ErrOutOfRange, got: ", err)
+	}
+
+	// the root must use the hash function of the hasher
+	other, _ := mh.Sum(nil, mh.SHA2_512, -1)
+	if err := h.VerifyInclusion(other, 0, 1, nil, nil); !errors.Is(err, ErrInvalidProof) {
+		t.Error("expected ErrInvalidProof, got: ", err)
+	}
+}
@@ -0,0 +1,207 @@
+package multihash
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// errors
+var (
+	// ErrUnknownMultibase is returned by ToString for unsupported
+	// encodings, and by FromString for strings which neither start
+	// with a supported multibase prefix nor are in bare base58.
+	ErrUnknownMultibase = errors.New("unknown multibase encoding")
+	// ErrAmbiguousString is returned by FromString for strings which
+	// decode to a valid multihash both as a multibase string and as
+	// a bare base58 string.
+	ErrAmbiguousString = errors.New("multihash string is both multibase and bare base58")
+)
+
+// Multibase is a multibase encoding, identified by the prefix
+// character of the strings it encodes.
+type Multibase byte
+
+// Multibase encodings. Unless they are padded, the encodings are the
+// unpadded variants of RFC 4648.
+const (
+	Base16         Multibase = 'f'
+	Base16Upper    Multibase = 'F'
+	Base32         Multibase = 'b'
+	Base32Upper    Multibase = 'B'
+	Base32Pad      Multibase = 'c'
+	Base32PadUpper Multibase = 'C'
+	Base32Hex      Multibase = 'v'
+	Base32HexUpper Multibase = 'V'
+	Base36         Multibase = 'k'
+	Base36Upper    Multibase = 'K'
+	Base58BTC      Multibase = 'z'
+	Base64         Multibase = 'm'
+	Base64Pad      Multibase = 'M'
+	Base64URL      Multibase = 'u'
+	Base64URLPad   Multibase = 'U'
+
+	// LegacyBase58 is the form of B58String: base58 without a prefix.
+	LegacyBase58 Multibase = 0
+)
+
+type multibaseEncoding struct {
+	encode func([]byte) string
+	decode func(string) ([]byte, error)
+}
+
+var (
+	base32Std = base32.StdEncoding.WithPadding(base32.NoPadding)
+	base32Hex = base32.HexEncoding.WithPadding(base32.NoPadding)
+)
+
+// multibases are the supported encodings. The decoders of the
+// case-insensitive bases accept both cases.
+var multibases = map[Multibase]multibaseEncoding{
+	Base16:         {hex.EncodeToString, hex.DecodeString},
+	Base16Upper:    {upper(hex.EncodeToString), hex.DecodeString},
+	Base32:         {lower(base32Std.EncodeToString), fromUpper(base32Std.DecodeString)},
+	Base32Upper:    {base32Std.EncodeToString, fromUpper(base32Std.DecodeString)},
+	Base32Pad:      {lower(base32.StdEncoding.EncodeToString), fromUpper(base32.StdEncoding.DecodeString)},
+	Base32PadUpper: {base32.StdEncoding.EncodeToString, fromUpper(base32.StdEncoding.DecodeString)},
+	Base32Hex:      {lower(base32Hex.EncodeToString), fromUpper(base32Hex.DecodeString)},
+	Base32HexUpper: {base32Hex.EncodeToString, fromUpper(base32Hex.DecodeString)},
+	Base36:         {encodeBase36, decodeBase36},
+	Base36Upper:    {upper(encodeBase36), decodeBase36},
+	Base58BTC:      {EncodeBase58, DecodeBase58},
+	Base64:         {base64.RawStdEncoding.EncodeToString, base64.RawStdEncoding.Strict().DecodeString},
+	Base64Pad:      {base64.StdEncoding.EncodeToString, base64.StdEncoding.Strict().DecodeString},
+	Base64URL:      {base64.RawURLEncoding.EncodeToString, base64.RawURLEncoding.Strict().DecodeString},
+	Base64URLPad:   {base64.URLEncoding.EncodeToString, base64.URLEncoding.Strict().DecodeString},
+}
+
+func upper(encode func([]byte) string) func([]byte) string {
+	return func(b []byte) string { return strings.ToUpper(encode(b)) }
+}
+
+func lower(encode func([]byte) string) func([]byte) string {
+	return func(b []byte) string { return strings.ToLower(encode(b)) }
+}
+
+func fromUpper(decode func(string) ([]byte, error)) func(string) ([]byte, error) {
+	return func(s string) ([]byte, error) { return decode(strings.ToUpper(s)) }
+}
+
+// ToString returns m encoded with the given multibase encoding,
+// prefix included, or in the legacy form of B58String for
+// LegacyBase58.
+func (m Multihash) ToString(base Multibase) (string, error) {
+	if base == LegacyBase58 {
+		return m.B58String(), nil
+	}
+
+	enc, ok := multibases[base]
+	if !ok {
+		return "", ErrUnknownMultibase
+	}
+	return string(base) + enc.encode(m), nil
+}
+
+// FromString parses a multihash encoded with any of the supported
+// multibase encodings, whose prefix tells which. It also accepts the
+// legacy form of B58String, with no prefix, unless the string is a
+// valid multihash in both forms: it then returns ErrAmbiguousString.
+func FromString(s string) (Multihash, error) {
+	if s == "" {
+		return Multihash{}, ErrInvalidMultihash
+	}
+
+	m, err := fromMultibase(s)
+	legacy, legacyErr := fromBareBase58(s)
+
+	switch {
+	case err == nil && legacyErr == nil:
+		return Multihash{}, ErrAmbiguousString
+	case err == nil:
+		return m, nil
+	case legacyErr == nil:
+		return legacy, nil
+	case errors.Is(err, ErrUnknownMultibase) && legacyErr != ErrUnknownMultibase:
+		// the string looks like bare base58
+		return Multihash{}, legacyErr
+	default:
+		return Multihash{}, err
+	}
+}
+
+func fromMultibase(s string) (Multihash, error) {
+	base := Multibase(s[0])
+	enc, ok := multibases[base]
+	if !ok {
+		return Multihash{}, ErrUnknownMultibase
+	}
+
+	b, err := enc.decode(s[1:])
+	if err != nil {
+		return Multihash{}, fmt.Errorf("invalid multibase %q string: %v", base, err)
+	}
+	return Cast(b)
+}
+
+// fromBareBase58 is FromB58String, which returns ErrUnknownMultibase
+// if s is not in base58.
+func fromBareBase58(s string) (Multihash, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return Multihash{}, ErrUnknownMultibase
+	}
+	return Cast(b)
+}
+
+const base36Alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// encodeBase36 encodes b as a big-endian number, with a zero digit for
+// each leading zero byte, as base58 does.
+func encodeBase36(b []byte) string {
+	var zeros int
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	var digits []byte
+	n := new(big.Int).SetBytes(b)
+	base, mod := big.NewInt(36), new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		digits = append(digits, base36Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, '0')
+	}
+
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+func decodeBase36(s string) ([]byte, error) {
+	s = strings.ToLower(s)
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == '0' {
+		zeros++
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(36)
+	for i := zeros; i < len(s); i++ {
+		d := strings.IndexByte(base36Alphabet, s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("invalid base36 character %q", s[i])
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
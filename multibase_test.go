@@ -0,0 +1,149 @@
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// The test vectors of the multibase specification.
+var multibaseTestCases = []struct {
+	base Multibase
+	data string
+	enc  string
+}{
+	{Base16, "yes mani !", "f796573206d616e692021"},
+	{Base16Upper, "yes mani !", "F796573206D616E692021"},
+	{Base32, "yes mani !", "bpfsxgidnmfxgsibb"},
+	{Base32Upper, "yes mani !", "BPFSXGIDNMFXGSIBB"},
+	{Base32Pad, "yes mani !", "cpfsxgidnmfxgsibb"},
+	{Base32PadUpper, "yes mani !", "CPFSXGIDNMFXGSIBB"},
+	{Base32Hex, "yes mani !", "vf5in683dc5n6i811"},
+	{Base32HexUpper, "yes mani !", "VF5IN683DC5N6I811"},
+	{Base36, "yes mani !", "k2lcpzo5yikidynfl"},
+	{Base36Upper, "yes mani !", "K2LCPZO5YIKIDYNFL"},
+	{Base58BTC, "yes mani !", "z7paNL19xttacUY"},
+	{Base64, "yes mani !", "meWVzIG1hbmkgIQ"},
+	{Base64Pad, "yes mani !", "MeWVzIG1hbmkgIQ=="},
+	{Base64URL, "yes mani !", "ueWVzIG1hbmkgIQ"},
+	{Base64URLPad, "yes mani !", "UeWVzIG1hbmkgIQ=="},
+	{Base36, "\x00yes mani !", "k02lcpzo5yikidynfl"},
+	{Base36, "\x00\x00yes mani !", "k002lcpzo5yikidynfl"},
+	{Base32Pad, "f", "cmy======"},
+	{Base64URL, "\xfb\xff", "u-_8"},
+}
+
+func TestMultibaseEncodings(t *testing.T) {
+	for _, tc := range multibaseTestCases {
+		enc := multibases[tc.base]
+		if s := string(tc.base) + enc.encode([]byte(tc.data)); s != tc.enc {
+			t.Errorf("%c: got %s, expected %s", tc.base, s, tc.enc)
+		}
+
+		b, err := enc.decode(tc.enc[1:])
+		if err != nil {
+			t.Errorf("%c: %s", tc.base, err)
+			continue
+		}
+		if string(b) != tc.data {
+			t.Errorf("%c: decoded %q, expected %q", tc.base, b, tc.data)
+		}
+	}
+}
+
+func TestToFromString(t *testing.T) {
+	for _, tc := range testCases {
+		m, err := tc.Multihash()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for base := range multibases {
+			s, err := m.ToString(base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s[0] != byte(base) {
+				t.Errorf("%s has not the prefix %c", s, base)
+			}
+
+			m2, err := FromString(s)
+			if err != nil {
+				t.Errorf("%s: %s", s, err)
+				continue
+			}
+			if !bytes.Equal(m, m2) {
+				t.Errorf("%s: decoded %x, expected %x", s, m2, m)
+			}
+		}
+
+		// the legacy form has no prefix
+		m2, err := FromString(m.B58String())
+		if err != nil {
+			t.Errorf("%s: %s", m.B58String(), err)
+		} else if !bytes.Equal(m, m2) {
+			t.Errorf("%s: decoded %x, expected %x", m.B58String(), m2, m)
+		}
+	}
+}
+
+func TestFromString(t *testing.T) {
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+
+	for _, s := range []string{
+		"QmRJzsvyCQyizr73Gmms8ZRtvNxmgqumxc2KUp71dfEmoj",
+		"f12202c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		"F12202C26B46B68FFC68FF99B453C1D30413413422D706483BFA0F98A5E886266E7AE",
+		"bciqcyjvunnup7rup7gnukpa5gbatie2cfvygja57ud4yuxuimjtoplq",
+		"BCIQCYJVUNNUP7RUP7GNUKPA5GBATIE2CFVYGJA57UD4YUXUIMJTOPLQ",
+		"Bciqcyjvunnup7rup7gnukpa5gbatie2cfvygja57ud4yuxuimjtoplq",
+		"zQmRJzsvyCQyizr73Gmms8ZRtvNxmgqumxc2KUp71dfEmoj",
+		"mEiAsJrRraP/Gj/mbRTwdMEE0E0ItcGSDv6D5il6IYmbnrg",
+		"uEiAsJrRraP_Gj_mbRTwdMEE0E0ItcGSDv6D5il6IYmbnrg",
+	} {
+		m2, err := FromString(s)
+		if err != nil {
+			t.Errorf("%s: %s", s, err)
+			continue
+		}
+		if !bytes.Equal(m, m2) {
+			t.Errorf("%s: decoded %x, expected %x", s, m2, m)
+		}
+	}
+}
+
+func TestFromStringErrors(t *testing.T) {
+	cases := []struct {
+		s   string
+		err error
+	}{
+		{"", ErrInvalidMultihash},
+		// not base58, and no multibase prefix
+		{"0x1220", ErrUnknownMultibase},
+		{"!foo", ErrUnknownMultibase},
+		// valid base58 and multibase strings, but not multihashes
+		{"z", ErrTooShort},
+		{"f1234", ErrTooShort},
+		{"Qm", ErrTooShort},
+		// both a base58btc multibase string and bare base58
+		{"zDvjmj7eHLApyYGX7wUyDXya2id9FeXZnnQbLmT9b", ErrAmbiguousString},
+	}
+
+	for _, tc := range cases {
+		if _, err := FromString(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("%q: expected %v, got %v", tc.s, tc.err, err)
+		}
+	}
+
+	// invalid characters for the base
+	for _, s := range []string{"f12zz", "mEi==", "kEi-"} {
+		if _, err := FromString(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+	if _, err := m.ToString('?'); err != ErrUnknownMultibase {
+		t.Error("expected ErrUnknownMultibase, got: ", err)
+	}
+}
@@ -9,29 +9,76 @@ import (
 	"errors"
 	"fmt"
 	"math"
-
-	b58 "gx/ipfs/QmT8rehPR3F6bmwL6zjUN8XpiDBFFpMP2myPdC6ApsWfJf/go-base58"
 )
 
 // errors
 var (
 	ErrUnknownCode      = errors.New("unknown multihash code")
 	ErrTooShort         = errors.New("multihash too short. must be > 3 bytes")
-	ErrTooLong          = errors.New("multihash too long. must be < 129 bytes")
-	ErrLenNotSupported  = errors.New("multihash does not yet support digests longer than 127 bytes")
+	ErrTooLong          = errors.New("multihash digest too long")
+	ErrLenNotSupported  = errors.New("multihash length not supported by hash function")
 	ErrInvalidMultihash = errors.New("input isn't valid multihash")
 
 	ErrVarintBufferShort = errors.New("uvarint: buffer too small")
 	ErrVarintTooLong     = errors.New("uvarint: varint too big (max 64bit)")
 )
 
-// ErrInconsistentLen is returned when a decoded multihash has an inconsistent length
-type ErrInconsistentLen struct {
-	dm *DecodedMultihash
-}
+// ErrInconsistentLen is the cause of an *Error returned when the
+// declared length of a multihash does not match its digest. Test for
+// it with errors.Is(err, ErrInconsistentLen{}).
+type ErrInconsistentLen struct{}
 
 func (e ErrInconsistentLen) Error() string {
-	return fmt.Sprintf("multihash length inconsistent: %v", e.dm)
+	return "multihash length inconsistent"
+}
+
+// Error is the type of the errors returned when decoding a multihash
+// or computing a digest fails. Err is always one of the sentinel
+// errors of this package, so errors.Is(err, ErrTooShort) and friends
+// can be used to classify errors, while errors.As gives access to the
+// details.
+type Error struct {
+	// Err is the cause of the error.
+	Err error
+	// Code is the multihash code, if it could be determined.
+	Code uint64
+	// Length is the declared (when decoding) or requested (when
+	// hashing) digest length, or -1 when unknown.
+	Length int
+	// Actual is the actual digest length, or -1 when unknown.
+	Actual int
+	// Offset is the byte offset in the input at which the error was
+	// detected, or -1 when not decoding.
+	Offset int
+}
+
+func (e *Error) Error() string {
+	s := fmt.Sprintf("%s (code 0x%x", e.Err, e.Code)
+	if e.Length >= 0 {
+		s += fmt.Sprintf(", length %d", e.Length)
+	}
+	if e.Actual >= 0 {
+		s += fmt.Sprintf(", actual %d", e.Actual)
+	}
+	if e.Offset >= 0 {
+		s += fmt.Sprintf(", offset %d", e.Offset)
+	}
+	return s + ")"
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// codeError returns an *Error about a code, outside of decoding.
+func codeError(err error, code uint64) *Error {
+	return &Error{Err: err, Code: code, Length: -1, Actual: -1, Offset: -1}
+}
+
+// lengthError returns an *Error about an unsupported digest length.
+func lengthError(code uint64, length, actual int) *Error {
+	return &Error{Err: ErrLenNotSupported, Code: code, Length: length, Actual: actual, Offset: -1}
 }
 
 // constants
@@ -50,9 +97,29 @@ const (
 	KECCAK_384 = 0x1C
 	KECCAK_512 = 0x1D
 
+	SHA2_224     = 0x1013
+	SHA2_384     = 0x20
+	SHA2_512_224 = 0x1014
+	SHA2_512_256 = 0x1015
+
 	SHAKE_128 = 0x18
 	SHAKE_256 = 0x19
 
+	BLAKE3 = 0x1E
+
+	KANGAROOTWELVE = 0x1d01
+
+	// The other functions of NIST SP 800-185 have no codes in the
+	// multicodec table, so they live in its private use range: each
+	// is 0x3c0000, 0x3c1000 or 0x3c2000 plus the code of the SHAKE
+	// function they are built on.
+	CSHAKE_128       = 0x3c0018
+	CSHAKE_256       = 0x3c0019
+	TUPLEHASH_128    = 0x3c1018
+	TUPLEHASH_256    = 0x3c1019
+	PARALLELHASH_128 = 0x3c2018
+	PARALLELHASH_256 = 0x3c2019
+
 	BLAKE2B_MIN = 0xB201
 	BLAKE2B_MAX = 0xB240
 	BLAKE2S_MIN = 0xB241
@@ -65,9 +132,64 @@ const (
 	SKEIN1024_MIN = 0xB361
 	SKEIN1024_MAX = 0xB3E0
 
+	SM3_256 = 0x534d
+
+	// Streebog (GOST R 34.11-2012) and Whirlpool have no codes in the
+	// multicodec table, so they live in its private use range.
+	STREEBOG_256 = 0x3d0020
+	STREEBOG_512 = 0x3d0040
+	WHIRLPOOL    = 0x3d1040
+
 	DBL_SHA2_256 = 0x56
 
-	MURMUR3 = 0x22
+	MD4        = 0xd4
+	MD5        = 0xd5
+	RIPEMD_160 = 0x1053
+
+	// HASH160 is Bitcoin's ripemd160(sha256(data)). It has no code
+	// in the multicodec table, so it lives in the private use range.
+	HASH160 = 0x301053
+
+	// MURMUR3 is the code 0x22, which used to be the 32-bit murmur3.
+	// This is a breaking change: 0x22 is now murmur3-x64-64, as in the
+	// multicodec table, so Sum gives 8 byte digests of another hash
+	// function for it, and 32-bit murmur3 digests encoded with 0x22 no
+	// longer verify. The 32-bit murmur3 is MURMUR3_32 (0x23). The old
+	// name "murmur3" remains an alias of 0x22.
+	//
+	// Deprecated: use MURMUR3_X64_64, or MURMUR3_32 for the 32-bit
+	// murmur3.
+	MURMUR3 = MURMUR3_X64_64
+
+	// The non-cryptographic hash functions.
+	MURMUR3_X64_64  = 0x22
+	MURMUR3_32      = 0x23
+	MURMUR3_X64_128 = 0x1022
+	XXH_32          = 0xb3e1
+	XXH_64          = 0xb3e2
+	XXH3_64         = 0xb3e3
+	XXH3_128        = 0xb3e4
+
+	// The multicodec table has no codes for message authentication
+	// codes, so these live in its private use range: each is 0x3a0000
+	// plus the code of the underlying hash function.
+	HMAC_SHA1     = 0x3a0011
+	HMAC_SHA2_256 = 0x3a0012
+	HMAC_SHA2_512 = 0x3a0013
+	KMAC_128      = 0x3a0018
+	KMAC_256      = 0x3a0019
+
+	KEYED_BLAKE2B_MIN = 0x3ab201
+	KEYED_BLAKE2B_MAX = 0x3ab240
+	KEYED_BLAKE2S_MIN = 0x3ab241
+	KEYED_BLAKE2S_MAX = 0x3ab260
+	KEYED_BLAKE3      = 0x3a001e
+
+	// BLAKE3_DERIVE_KEY is BLAKE3 in key derivation mode: the key
+	// given to NewKeyedHasher is the context string, and the data
+	// is the key material. Key derivation functions are 0x3b0000
+	// plus the code of the underlying hash function.
+	BLAKE3_DERIVE_KEY = 0x3b001e
 )
 
 func init() {
@@ -115,67 +237,228 @@ func init() {
 		Codes[c] = name
 		DefaultLengths[c] = int(n)
 	}
+
+	// Add keyed blake2b (64 codes)
+	for c := uint64(KEYED_BLAKE2B_MIN); c <= KEYED_BLAKE2B_MAX; c++ {
+		n := c - KEYED_BLAKE2B_MIN + 1
+		name := fmt.Sprintf("keyed-blake2b-%d", n*8)
+		Names[name] = c
+		Codes[c] = name
+		DefaultLengths[c] = int(n)
+	}
+
+	// Add keyed blake2s (32 codes)
+	for c := uint64(KEYED_BLAKE2S_MIN); c <= KEYED_BLAKE2S_MAX; c++ {
+		n := c - KEYED_BLAKE2S_MIN + 1
+		name := fmt.Sprintf("keyed-blake2s-%d", n*8)
+		Names[name] = c
+		Codes[c] = name
+		DefaultLengths[c] = int(n)
+	}
+
+	// Register all of the above
+	for c, name := range Codes {
+		var err error
+		if kf := builtinKeyedHashFunc(c); kf != nil {
+			err = RegisterKeyed(c, name, DefaultLengths[c], kf)
+		} else {
+			err = Register(c, name, DefaultLengths[c], builtinHashFunc(c))
+		}
+		if err != nil {
+			panic(fmt.Sprintf("registering %s: %s", name, err))
+		}
+	}
+	// and aliases like "sha3"
+	for name, c := range Names {
+		if Codes[c] != name {
+			registerAlias(name, c)
+		}
+	}
 }
 
-// Names maps the name of a hash to the code
+// Names maps the name of a hash to the code.
+//
+// Deprecated: Names only describes the built-in hash functions and
+// changing it has no effect. Use LookupCode instead.
 var Names = map[string]uint64{
 	"id":           ID,
 	"sha1":         SHA1,
 	"sha2-256":     SHA2_256,
 	"sha2-512":     SHA2_512,
+	"sha2-224":     SHA2_224,
+	"sha2-384":     SHA2_384,
+	"sha2-512-224": SHA2_512_224,
+	"sha2-512-256": SHA2_512_256,
 	"sha3":         SHA3_512,
 	"sha3-224":     SHA3_224,
 	"sha3-256":     SHA3_256,
 	"sha3-384":     SHA3_384,
 	"sha3-512":     SHA3_512,
 	"dbl-sha2-256": DBL_SHA2_256,
-	"murmur3":      MURMUR3,
+	"md4":          MD4,
+	"md5":          MD5,
+	"ripemd-160":   RIPEMD_160,
+	"hash160":      HASH160,
 	"keccak-224":   KECCAK_224,
 	"keccak-256":   KECCAK_256,
 	"keccak-384":   KECCAK_384,
 	"keccak-512":   KECCAK_512,
 	"shake-128":    SHAKE_128,
 	"shake-256":    SHAKE_256,
+	"blake3":       BLAKE3,
+
+	"murmur3":         MURMUR3,
+	"murmur3-x64-64":  MURMUR3_X64_64,
+	"murmur3-32":      MURMUR3_32,
+	"murmur3-x64-128": MURMUR3_X64_128,
+	"xxh-32":          XXH_32,
+	"xxh-64":          XXH_64,
+	"xxh3-64":         XXH3_64,
+	"xxh3-128":        XXH3_128,
+
+	"sm3-256":      SM3_256,
+	"streebog-256": STREEBOG_256,
+	"streebog-512": STREEBOG_512,
+	"whirlpool":    WHIRLPOOL,
+
+	"kangarootwelve":   KANGAROOTWELVE,
+	"cshake-128":       CSHAKE_128,
+	"cshake-256":       CSHAKE_256,
+	"tuplehash-128":    TUPLEHASH_128,
+	"tuplehash-256":    TUPLEHASH_256,
+	"parallelhash-128": PARALLELHASH_128,
+	"parallelhash-256": PARALLELHASH_256,
+
+	"hmac-sha1":     HMAC_SHA1,
+	"hmac-sha2-256": HMAC_SHA2_256,
+	"hmac-sha2-512": HMAC_SHA2_512,
+	"kmac-128":      KMAC_128,
+	"kmac-256":      KMAC_256,
+
+	"keyed-blake3":      KEYED_BLAKE3,
+	"blake3-derive-key": BLAKE3_DERIVE_KEY,
 }
 
-// Codes maps a hash code to it's name
+// Codes maps a hash code to it's name.
+//
+// Deprecated: Codes only describes the built-in hash functions and
+// changing it has no effect. Use LookupName instead.
 var Codes = map[uint64]string{
 	ID:           "id",
 	SHA1:         "sha1",
 	SHA2_256:     "sha2-256",
 	SHA2_512:     "sha2-512",
+	SHA2_224:     "sha2-224",
+	SHA2_384:     "sha2-384",
+	SHA2_512_224: "sha2-512-224",
+	SHA2_512_256: "sha2-512-256",
 	SHA3_224:     "sha3-224",
 	SHA3_256:     "sha3-256",
 	SHA3_384:     "sha3-384",
 	SHA3_512:     "sha3-512",
 	DBL_SHA2_256: "dbl-sha2-256",
-	MURMUR3:      "murmur3",
+	MD4:          "md4",
+	MD5:          "md5",
+	RIPEMD_160:   "ripemd-160",
+	HASH160:      "hash160",
 	KECCAK_224:   "keccak-224",
 	KECCAK_256:   "keccak-256",
 	KECCAK_384:   "keccak-384",
 	KECCAK_512:   "keccak-512",
 	SHAKE_128:    "shake-128",
 	SHAKE_256:    "shake-256",
+	BLAKE3:       "blake3",
+
+	MURMUR3_X64_64:  "murmur3-x64-64",
+	MURMUR3_32:      "murmur3-32",
+	MURMUR3_X64_128: "murmur3-x64-128",
+	XXH_32:          "xxh-32",
+	XXH_64:          "xxh-64",
+	XXH3_64:         "xxh3-64",
+	XXH3_128:        "xxh3-128",
+
+	SM3_256:      "sm3-256",
+	STREEBOG_256: "streebog-256",
+	STREEBOG_512: "streebog-512",
+	WHIRLPOOL:    "whirlpool",
+
+	KANGAROOTWELVE:   "kangarootwelve",
+	CSHAKE_128:       "cshake-128",
+	CSHAKE_256:       "cshake-256",
+	TUPLEHASH_128:    "tuplehash-128",
+	TUPLEHASH_256:    "tuplehash-256",
+	PARALLELHASH_128: "parallelhash-128",
+	PARALLELHASH_256: "parallelhash-256",
+
+	HMAC_SHA1:     "hmac-sha1",
+	HMAC_SHA2_256: "hmac-sha2-256",
+	HMAC_SHA2_512: "hmac-sha2-512",
+	KMAC_128:      "kmac-128",
+	KMAC_256:      "kmac-256",
+
+	KEYED_BLAKE3:      "keyed-blake3",
+	BLAKE3_DERIVE_KEY: "blake3-derive-key",
 }
 
-// DefaultLengths maps a hash code to it's default length
+// DefaultLengths maps a hash code to it's default length.
+//
+// Deprecated: DefaultLengths only describes the built-in hash functions
+// and changing it has no effect. Use LookupDefaultLength instead.
 var DefaultLengths = map[uint64]int{
 	ID:           32,
 	SHA1:         20,
 	SHA2_256:     32,
 	SHA2_512:     64,
+	SHA2_224:     28,
+	SHA2_384:     48,
+	SHA2_512_224: 28,
+	SHA2_512_256: 32,
 	SHA3_224:     28,
 	SHA3_256:     32,
 	SHA3_384:     48,
 	SHA3_512:     64,
 	DBL_SHA2_256: 32,
+	MD4:          16,
+	MD5:          16,
+	RIPEMD_160:   20,
+	HASH160:      20,
 	KECCAK_224:   28,
 	KECCAK_256:   32,
-	MURMUR3:      4,
 	KECCAK_384:   48,
 	KECCAK_512:   64,
 	SHAKE_128:    32,
 	SHAKE_256:    64,
+	BLAKE3:       32,
+
+	MURMUR3_X64_64:  8,
+	MURMUR3_32:      4,
+	MURMUR3_X64_128: 16,
+	XXH_32:          4,
+	XXH_64:          8,
+	XXH3_64:         8,
+	XXH3_128:        16,
+
+	SM3_256:      32,
+	STREEBOG_256: 32,
+	STREEBOG_512: 64,
+	WHIRLPOOL:    64,
+
+	KANGAROOTWELVE:   32,
+	CSHAKE_128:       32,
+	CSHAKE_256:       64,
+	TUPLEHASH_128:    32,
+	TUPLEHASH_256:    64,
+	PARALLELHASH_128: 32,
+	PARALLELHASH_256: 64,
+
+	HMAC_SHA1:     20,
+	HMAC_SHA2_256: 32,
+	HMAC_SHA2_512: 64,
+	KMAC_128:      32,
+	KMAC_256:      64,
+
+	KEYED_BLAKE3:      32,
+	BLAKE3_DERIVE_KEY: 32,
 }
 
 func uvarint(buf []byte) (uint64, []byte, error) {
@@ -226,22 +509,15 @@ func FromHexString(s string) (Multihash, error) {
 
 // B58String returns the B58-encoded representation of a multihash.
 func (m Multihash) B58String() string {
-	return b58.Encode([]byte(m))
+	return EncodeBase58([]byte(m))
 }
 
-// FromB58String parses a B58-encoded multihash.
-func FromB58String(s string) (m Multihash, err error) {
-	// panic handler, in case we try accessing bytes incorrectly.
-	defer func() {
-		if e := recover(); e != nil {
-			m = Multihash{}
-			err = e.(error)
-		}
-	}()
-
-	//b58 smells like it can panic...
-	b := b58.Decode(s)
-	if len(b) == 0 {
+// FromB58String parses a B58-encoded multihash. It returns
+// ErrInvalidMultihash for strings which are not in base58: use
+// DecodeBase58 to know which character is invalid.
+func FromB58String(s string) (Multihash, error) {
+	b, err := DecodeBase58(s)
+	if err != nil || len(b) == 0 {
 		return Multihash{}, ErrInvalidMultihash
 	}
 
@@ -257,17 +533,19 @@ func Cast(buf []byte) (Multihash, error) {
 	}
 
 	if !ValidCode(dm.Code) {
-		return Multihash{}, ErrUnknownCode
+		return Multihash{}, &Error{Err: ErrUnknownCode, Code: dm.Code, Length: dm.Length, Actual: len(dm.Digest), Offset: 0}
 	}
 
 	return Multihash(buf), nil
 }
 
 // Decode parses multihash bytes into a DecodedMultihash.
+// Errors are of type *Error.
 func Decode(buf []byte) (*DecodedMultihash, error) {
+	total := len(buf)
 
-	if len(buf) < 3 {
-		return nil, ErrTooShort
+	if total < 3 {
+		return nil, &Error{Err: ErrTooShort, Length: -1, Actual: -1, Offset: total}
 	}
 
 	var err error
@@ -275,27 +553,29 @@ func Decode(buf []byte) (*DecodedMultihash, error) {
 
 	code, buf, err = uvarint(buf)
 	if err != nil {
-		return nil, err
+		return nil, &Error{Err: err, Length: -1, Actual: -1, Offset: 0}
 	}
 
+	offset := total - len(buf)
 	length, buf, err = uvarint(buf)
 	if err != nil {
-		return nil, err
+		return nil, &Error{Err: err, Code: code, Length: -1, Actual: -1, Offset: offset}
 	}
 
 	if length > math.MaxInt32 {
-		return nil, errors.New("digest too long, supporting only <= 2^31-1")
+		return nil, &Error{Err: ErrTooLong, Code: code, Length: -1, Actual: len(buf), Offset: offset}
 	}
 
+	name, _ := LookupName(code)
 	dm := &DecodedMultihash{
 		Code:   code,
-		Name:   Codes[code],
+		Name:   name,
 		Length: int(length),
 		Digest: buf,
 	}
 
 	if len(dm.Digest) != dm.Length {
-		return nil, ErrInconsistentLen{dm}
+		return nil, &Error{Err: ErrInconsistentLen{}, Code: code, Length: dm.Length, Actual: len(dm.Digest), Offset: total - len(buf)}
 	}
 
 	return dm, nil
@@ -306,7 +586,7 @@ func Decode(buf []byte) (*DecodedMultihash, error) {
 func Encode(buf []byte, code uint64) ([]byte, error) {
 
 	if !ValidCode(code) {
-		return nil, ErrUnknownCode
+		return nil, codeError(ErrUnknownCode, code)
 	}
 
 	start := make([]byte, 2*binary.MaxVarintLen64)
@@ -319,9 +599,13 @@ func Encode(buf []byte, code uint64) ([]byte, error) {
 }
 
 // EncodeName is like Encode() but providing a string name
-// instead of a numeric code. See Names for allowed values.
+// instead of a numeric code. See Register for adding names.
 func EncodeName(buf []byte, name string) ([]byte, error) {
-	return Encode(buf, Names[name])
+	code, ok := LookupCode(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCode, name)
+	}
+	return Encode(buf, code)
 }
 
 // ValidCode checks whether a multihash code is valid.
@@ -330,7 +614,7 @@ func ValidCode(code uint64) bool {
 		return true
 	}
 
-	if _, ok := Codes[code]; ok {
+	if _, ok := lookupCode(code); ok {
 		return true
 	}
 
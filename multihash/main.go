@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
@@ -24,6 +25,8 @@ var checkMh mh.Multihash
 var inputFilename string
 var quiet bool
 var help bool
+var chunkAlgo string
+var chunkSize int
 
 func init() {
 	flag.Usage = func() {
@@ -44,6 +47,10 @@ func init() {
 	quietStr := "quiet output (no newline on checksum, no error text)"
 	flag.BoolVar(&quiet, "quiet", false, quietStr)
 	flag.BoolVar(&quiet, "q", false, quietStr+" (shorthand)")
+
+	chunkStr := "print a manifest of the content-defined chunks: offset, length and multihash of each. one of: " + mh.ChunkFastCDC + ", " + mh.ChunkRabin
+	flag.StringVar(&chunkAlgo, "chunk", "", chunkStr)
+	flag.IntVar(&chunkSize, "chunk-size", mh.DefaultChunkAvgSize, "average chunk size in bytes, a power of two")
 }
 
 func parseFlags(o *mhopts.Options) error {
@@ -52,6 +59,15 @@ func parseFlags(o *mhopts.Options) error {
 		return err
 	}
 
+	if chunkAlgo != "" {
+		if checkRaw != "" || o.Git {
+			return fmt.Errorf("-chunk cannot be used with -check or -git")
+		}
+		if o.Encoding == "raw" {
+			return fmt.Errorf("chunk manifests cannot use the raw encoding")
+		}
+	}
+
 	if checkRaw != "" {
 		var err error
 		checkMh, err = mhopts.Decode(o.Encoding, checkRaw)
@@ -82,12 +98,30 @@ func getInput() (io.ReadCloser, error) {
 		return f, nil
 	}
 }
+
+// gitDirectory returns the FILE argument if it is a directory to hash
+// as a git tree.
+func gitDirectory(o *mhopts.Options) string {
+	args := flag.Args()
+	if !o.Git || len(args) < 1 || args[0] == "-" {
+		return ""
+	}
+
+	if fi, err := os.Stat(args[0]); err != nil || !fi.IsDir() {
+		return ""
+	}
+	return args[0]
+}
+
 func printHash(o *mhopts.Options, r io.Reader) error {
 	h, err := o.Multihash(r)
 	if err != nil {
 		return err
 	}
+	return printMultihash(o, h)
+}
 
+func printMultihash(o *mhopts.Options, h mh.Multihash) error {
 	s, err := mhopts.Encode(o.Encoding, h)
 	if err != nil {
 		return err
@@ -101,6 +135,34 @@ func printHash(o *mhopts.Options, r io.Reader) error {
 	return nil
 }
 
+// printChunks prints the manifest of the chunks of r, one chunk per
+// line.
+func printChunks(o *mhopts.Options, r io.Reader) error {
+	c, err := mh.NewChunker(r, o.AlgorithmCode, o.Length, mh.ChunkerOptions{
+		Algorithm: chunkAlgo,
+		AvgSize:   chunkSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := c.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		s, err := mhopts.Encode(o.Encoding, chunk.Multihash)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d %d %s\n", chunk.Offset, chunk.Length, s)
+	}
+}
+
 func main() {
 	checkErr := func(err error) {
 		if err != nil {
@@ -116,10 +178,30 @@ func main() {
 		os.Exit(0)
 	}
 
+	if dir := gitDirectory(opts); dir != "" {
+		h, err := mh.SumGitDirectory(dir, opts.AlgorithmCode)
+		checkErr(err)
+
+		if checkMh != nil {
+			if !bytes.Equal(h, checkMh) {
+				checkErr(mhopts.ErrMatch)
+			}
+			if !quiet {
+				fmt.Println("OK checksums match (-q for no output)")
+			}
+		} else {
+			checkErr(printMultihash(opts, h))
+		}
+		return
+	}
+
 	inp, err := getInput()
 	checkErr(err)
 
-	if checkMh != nil {
+	if chunkAlgo != "" {
+		err = printChunks(opts, inp)
+		checkErr(err)
+	} else if checkMh != nil {
 		err = opts.Check(inp, checkMh)
 		checkErr(err)
 		if !quiet {
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -20,13 +22,18 @@ var tCodes = map[uint64]string{
 	0x16: "sha3-256",
 	0x17: "sha3-224",
 	0x56: "dbl-sha2-256",
-	0x22: "murmur3",
+	0x22: "murmur3-x64-64",
+	0x23: "murmur3-32",
 	0x1A: "keccak-224",
 	0x1B: "keccak-256",
 	0x1C: "keccak-384",
 	0x1D: "keccak-512",
 	0x18: "shake-128",
 	0x19: "shake-256",
+	0x1E: "blake3",
+	0x20: "sha2-384",
+	0xd4: "md4",
+	0xd5: "md5",
 }
 
 type TestCase struct {
@@ -42,7 +49,8 @@ var testCases = []TestCase{
 	TestCase{"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", 0x12, "sha2-256"},
 	TestCase{"2c26b46b", 0x12, "sha2-256"},
 	TestCase{"2c26b46b68ffc68ff99b453c1d30413413", 0xb240, "blake2b-512"},
-	TestCase{"243ddb9e", 0x22, "murmur3"},
+	// 0x22 was named murmur3, for the 32-bit murmur3
+	TestCase{"243ddb9e", 0x22, "murmur3-x64-64"},
 	TestCase{"f00ba4", 0x1b, "keccak-256"},
 	TestCase{"f84e95cb5fbd2038863ab27d3cdeac295ad2d4ab96ad1f4b070c0bf36078ef08", 0x18, "shake-128"},
 	TestCase{"1af97f7818a28edfdfce5ec66dbdc7e871813816d7d585fe1f12475ded5b6502b7723b74e2ee36f2651a10a8eaca72aa9148c3c761aaceac8f6d6cc64381ed39", 0x19, "shake-256"},
@@ -276,15 +284,105 @@ func TestDecodeErrorInvalid(t *testing.T) {
 
 func TestBadVarint(t *testing.T) {
 	_, err := Cast([]byte{129, 128, 128, 128, 128, 128, 128, 128, 128, 128, 129, 1})
-	if err != ErrVarintTooLong {
+	if !errors.Is(err, ErrVarintTooLong) {
 		t.Error("expected error from varint longer than 64bits, got: ", err)
 	}
 	_, err = Cast([]byte{128, 128, 128})
-	if err != ErrVarintBufferShort {
+	if !errors.Is(err, ErrVarintBufferShort) {
 		t.Error("expected error from cut-off varint, got: ", err)
 	}
 }
 
+func TestDecodeErrors(t *testing.T) {
+	cases := []struct {
+		hex    string
+		err    error
+		code   uint64
+		length int
+		actual int
+		offset int
+	}{
+		{"1204", ErrTooShort, 0, -1, -1, 2},
+		{"ffffffffffffffffffff01", ErrVarintTooLong, 0, -1, -1, 0},
+		{"1280808080", ErrVarintBufferShort, 0x12, -1, -1, 1},
+		{"1280808080800100", ErrTooLong, 0x12, -1, 1, 1},
+		{"12202c26b46b", ErrInconsistentLen{}, 0x12, 32, 4, 2},
+		{"1202aabbcc", ErrInconsistentLen{}, 0x12, 2, 3, 2},
+		{"9fff0302aabb", ErrUnknownCode, 0xff9f, 2, 2, 0},
+	}
+
+	for _, tc := range cases {
+		buf, err := hex.DecodeString(tc.hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Cast(buf)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %s, got: %v", tc.hex, tc.err, err)
+			continue
+		}
+
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s: expected an *Error, got: %T", tc.hex, err)
+			continue
+		}
+
+		if e.Code != tc.code || e.Length != tc.length || e.Actual != tc.actual || e.Offset != tc.offset {
+			t.Errorf("%s: wrong error details: %+v", tc.hex, e)
+		}
+	}
+}
+
+func TestNeverPanics(t *testing.T) {
+	// all the prefixes of a few multihashes, and some junk
+	var inputs [][]byte
+	for _, tc := range testCases {
+		m, _ := tc.Multihash()
+		for i := 0; i <= len(m); i++ {
+			inputs = append(inputs, m[:i])
+		}
+	}
+	inputs = append(inputs, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	for _, in := range inputs {
+		Decode(in)
+		Cast(in)
+		FromHexString(hex.EncodeToString(in))
+	}
+
+	for _, code := range []uint64{ID, SHA1, SHA2_256, SHAKE_128, BLAKE2B_MIN, SKEIN256_MIN, MURMUR3_X64_64, 0x0f, 0xdeadbeef} {
+		for _, length := range []int{-1, 0, 1, 1000, math.MaxInt32} {
+			Sum([]byte("foo"), code, length)
+		}
+	}
+}
+
+func TestSumErrors(t *testing.T) {
+	_, err := Sum([]byte("foo"), SHA1, 21)
+	if !errors.Is(err, ErrLenNotSupported) {
+		t.Fatal("expected ErrLenNotSupported, got: ", err)
+	}
+
+	var e *Error
+	if !errors.As(err, &e) || e.Code != SHA1 || e.Length != 21 || e.Actual != 20 {
+		t.Errorf("wrong error details: %+v", e)
+	}
+
+	if _, err := Sum([]byte("foo"), 0xdeadbeef, -1); !errors.Is(err, ErrUnknownCode) {
+		t.Error("expected ErrUnknownCode, got: ", err)
+	}
+
+	if _, err := Sum([]byte("foo"), 0x0f, -1); !errors.Is(err, ErrSumNotSupported) {
+		t.Error("expected ErrSumNotSupported, got: ", err)
+	}
+
+	if _, err := Sum([]byte("foo"), ID, 4); !errors.Is(err, ErrLenNotSupported) {
+		t.Error("expected ErrLenNotSupported, got: ", err)
+	}
+}
+
 func BenchmarkEncode(b *testing.B) {
 	tc := testCases[0]
 	ob, err := hex.DecodeString(tc.hex)
@@ -0,0 +1,112 @@
+package multihash
+
+import (
+	"io"
+	"sync"
+)
+
+// HashSpec selects a hash function and a digest length, as passed to
+// Sum. A negative Length selects the default length.
+type HashSpec struct {
+	Code   uint64
+	Length int
+}
+
+// parallelMinWrite is the smallest write which a parallel MultiHasher
+// spreads over goroutines. Smaller writes are not worth it.
+const parallelMinWrite = 16 << 10
+
+// MultiHasher is an io.Writer which feeds the data written to it to
+// several Hashers, so that as many multihashes of the same data are
+// computed in a single pass.
+type MultiHasher struct {
+	hashers  []Hasher
+	parallel bool
+}
+
+// NewMultiHasher returns a MultiHasher for the given hash functions.
+// If parallel is true, large writes are hashed by every hash function
+// in its own goroutine.
+func NewMultiHasher(parallel bool, specs ...HashSpec) (*MultiHasher, error) {
+	m := &MultiHasher{
+		hashers:  make([]Hasher, len(specs)),
+		parallel: parallel,
+	}
+
+	for i, s := range specs {
+		h, err := NewHasher(s.Code, s.Length)
+		if err != nil {
+			return nil, err
+		}
+		m.hashers[i] = h
+	}
+	return m, nil
+}
+
+func (m *MultiHasher) Write(p []byte) (int, error) {
+	if !m.parallel || len(m.hashers) < 2 || len(p) < parallelMinWrite {
+		for _, h := range m.hashers {
+			h.Write(p)
+		}
+		return len(p), nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(m.hashers))
+	for _, h := range m.hashers {
+		go func(h Hasher) {
+			defer wg.Done()
+			h.Write(p)
+		}(h)
+	}
+	wg.Wait()
+	return len(p), nil
+}
+
+// Reset resets all the hash functions.
+func (m *MultiHasher) Reset() {
+	for _, h := range m.hashers {
+		h.Reset()
+	}
+}
+
+// Multihashes returns the multihashes of the data written so far, in
+// the order of the specs given to NewMultiHasher.
+func (m *MultiHasher) Multihashes() ([]Multihash, error) {
+	out := make([]Multihash, len(m.hashers))
+	for i, h := range m.hashers {
+		mh, err := h.Multihash()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = mh
+	}
+	return out, nil
+}
+
+// MultiSum is like Sum for several hash functions at once. The
+// multihashes are returned in the order of the specs.
+func MultiSum(data []byte, specs ...HashSpec) ([]Multihash, error) {
+	m, err := NewMultiHasher(false, specs...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Write(data)
+	return m.Multihashes()
+}
+
+// MultiSumReader is like MultiSum, but hashes the data read from r,
+// in a single pass. See NewMultiHasher for parallel.
+func MultiSumReader(r io.Reader, parallel bool, specs ...HashSpec) ([]Multihash, error) {
+	m, err := NewMultiHasher(parallel, specs...)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 256<<10)
+	if _, err := io.CopyBuffer(m, r, buf); err != nil {
+		return nil, err
+	}
+	return m.Multihashes()
+}
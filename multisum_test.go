@@ -0,0 +1,90 @@
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var multiSumSpecs = []HashSpec{
+	{SHA2_256, -1},
+	{BLAKE2B_MIN + 31, -1},
+	{SHA3_512, 20},
+	{SHAKE_256, 100},
+}
+
+func TestMultiSum(t *testing.T) {
+	data := bytes.Repeat([]byte("migrate me "), 10000)
+
+	ms, err := MultiSum(data, multiSumSpecs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ms) != len(multiSumSpecs) {
+		t.Fatal("wrong number of multihashes: ", len(ms))
+	}
+
+	for _, parallel := range []bool{false, true} {
+		ms2, err := MultiSumReader(bytes.NewReader(data), parallel, multiSumSpecs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, s := range multiSumSpecs {
+			ref, err := Sum(data, s.Code, s.Length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ms[i], ref) {
+				t.Errorf("%s: MultiSum differs from Sum", Codes[s.Code])
+			}
+			if !bytes.Equal(ms2[i], ref) {
+				t.Errorf("%s: MultiSumReader (parallel: %v) differs from Sum", Codes[s.Code], parallel)
+			}
+		}
+	}
+}
+
+func TestMultiHasherReset(t *testing.T) {
+	m, err := NewMultiHasher(true, multiSumSpecs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Write(make([]byte, 1<<20))
+	m.Reset()
+	m.Write([]byte("foo"))
+
+	ms, err := m.Multihashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, _ := MultiSum([]byte("foo"), multiSumSpecs...)
+	for i := range ms {
+		if !bytes.Equal(ms[i], ref[i]) {
+			t.Errorf("%d: wrong multihash after reset", i)
+		}
+	}
+}
+
+func TestMultiSumErrors(t *testing.T) {
+	if _, err := MultiSum(nil, HashSpec{SHA1, -1}, HashSpec{0xdeadbeef, -1}); !errors.Is(err, ErrUnknownCode) {
+		t.Error("expected ErrUnknownCode, got: ", err)
+	}
+	if _, err := MultiSum(nil, HashSpec{SHA1, 21}); !errors.Is(err, ErrLenNotSupported) {
+		t.Error("expected ErrLenNotSupported, got: ", err)
+	}
+}
+
+func BenchmarkMultiSumReader(b *testing.B) {
+	data := make([]byte, 4<<20)
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		if _, err := MultiSumReader(bytes.NewReader(data), true, multiSumSpecs...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
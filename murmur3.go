@@ -0,0 +1,250 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// This is MurmurHash3, which is not a cryptographic hash function.
+// Digests are serialized like the reference implementation writes
+// them to memory on a little-endian machine: the 32-bit hash in
+// little-endian order, and the two 64-bit halves of the x64 128-bit
+// hash, h1 first, each in little-endian order. The x64 64-bit hash is
+// h1 alone, so it is the first 8 bytes of the 128-bit digest.
+
+const (
+	murmur3C1_32 = 0xcc9e2d51
+	murmur3C2_32 = 0x1b873593
+
+	murmur3C1_128 = 0x87c37b91114253d5
+	murmur3C2_128 = 0x4cf5ad432745937f
+)
+
+// murmur3_32 is the x86 32-bit variant.
+type murmur3_32 struct {
+	seed uint32
+	h    uint32
+	buf  [4]byte
+	nbuf int
+	n    uint64
+}
+
+func newMurmur3_32(seed uint32) *murmur3_32 {
+	return &murmur3_32{seed: seed, h: seed}
+}
+
+func (m *murmur3_32) Reset() {
+	*m = murmur3_32{seed: m.seed, h: m.seed}
+}
+
+func (m *murmur3_32) Size() int      { return 4 }
+func (m *murmur3_32) BlockSize() int { return 4 }
+
+func (m *murmur3_32) Write(p []byte) (int, error) {
+	n := len(p)
+	m.n += uint64(n)
+
+	if m.nbuf > 0 {
+		c := copy(m.buf[m.nbuf:], p)
+		m.nbuf += c
+		p = p[c:]
+		if m.nbuf < 4 {
+			return n, nil
+		}
+		m.h = murmur3Block32(m.h, binary.LittleEndian.Uint32(m.buf[:]))
+		m.nbuf = 0
+	}
+
+	for ; len(p) >= 4; p = p[4:] {
+		m.h = murmur3Block32(m.h, binary.LittleEndian.Uint32(p))
+	}
+	m.nbuf = copy(m.buf[:], p)
+	return n, nil
+}
+
+func murmur3Block32(h, k uint32) uint32 {
+	k *= murmur3C1_32
+	k = bits.RotateLeft32(k, 15)
+	k *= murmur3C2_32
+
+	h ^= k
+	h = bits.RotateLeft32(h, 13)
+	return h*5 + 0xe6546b64
+}
+
+func (m *murmur3_32) Sum32() uint32 {
+	h := m.h
+
+	var k uint32
+	switch m.nbuf {
+	case 3:
+		k ^= uint32(m.buf[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(m.buf[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(m.buf[0])
+		k *= murmur3C1_32
+		k = bits.RotateLeft32(k, 15)
+		k *= murmur3C2_32
+		h ^= k
+	}
+
+	h ^= uint32(m.n)
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+func (m *murmur3_32) Sum(b []byte) []byte {
+	var d [4]byte
+	binary.LittleEndian.PutUint32(d[:], m.Sum32())
+	return append(b, d[:]...)
+}
+
+// murmur3_128 is the x64 128-bit variant.
+type murmur3_128 struct {
+	seed   uint32
+	h1, h2 uint64
+	buf    [16]byte
+	nbuf   int
+	n      uint64
+}
+
+func newMurmur3_128(seed uint32) *murmur3_128 {
+	return &murmur3_128{seed: seed, h1: uint64(seed), h2: uint64(seed)}
+}
+
+func (m *murmur3_128) Reset() {
+	*m = *newMurmur3_128(m.seed)
+}
+
+func (m *murmur3_128) Size() int      { return 16 }
+func (m *murmur3_128) BlockSize() int { return 16 }
+
+func (m *murmur3_128) Write(p []byte) (int, error) {
+	n := len(p)
+	m.n += uint64(n)
+
+	if m.nbuf > 0 {
+		c := copy(m.buf[m.nbuf:], p)
+		m.nbuf += c
+		p = p[c:]
+		if m.nbuf < 16 {
+			return n, nil
+		}
+		m.block(m.buf[:])
+		m.nbuf = 0
+	}
+
+	for ; len(p) >= 16; p = p[16:] {
+		m.block(p)
+	}
+	m.nbuf = copy(m.buf[:], p)
+	return n, nil
+}
+
+func (m *murmur3_128) block(p []byte) {
+	k1 := binary.LittleEndian.Uint64(p)
+	k2 := binary.LittleEndian.Uint64(p[8:])
+	h1, h2 := m.h1, m.h2
+
+	k1 *= murmur3C1_128
+	k1 = bits.RotateLeft64(k1, 31)
+	k1 *= murmur3C2_128
+	h1 ^= k1
+
+	h1 = bits.RotateLeft64(h1, 27)
+	h1 += h2
+	h1 = h1*5 + 0x52dce729
+
+	k2 *= murmur3C2_128
+	k2 = bits.RotateLeft64(k2, 33)
+	k2 *= murmur3C1_128
+	h2 ^= k2
+
+	h2 = bits.RotateLeft64(h2, 31)
+	h2 += h1
+	h2 = h2*5 + 0x38495ab5
+
+	m.h1, m.h2 = h1, h2
+}
+
+// Sum128 returns the two halves of the hash.
+func (m *murmur3_128) Sum128() (uint64, uint64) {
+	h1, h2 := m.h1, m.h2
+
+	// the tail is zero padded
+	var tail [16]byte
+	copy(tail[:], m.buf[:m.nbuf])
+	k1 := binary.LittleEndian.Uint64(tail[:])
+	k2 := binary.LittleEndian.Uint64(tail[8:])
+
+	if m.nbuf > 8 {
+		k2 *= murmur3C2_128
+		k2 = bits.RotateLeft64(k2, 33)
+		k2 *= murmur3C1_128
+		h2 ^= k2
+	}
+	if m.nbuf > 0 {
+		k1 *= murmur3C1_128
+		k1 = bits.RotateLeft64(k1, 31)
+		k1 *= murmur3C2_128
+		h1 ^= k1
+	}
+
+	h1 ^= m.n
+	h2 ^= m.n
+
+	h1 += h2
+	h2 += h1
+
+	h1 = murmur3Fmix64(h1)
+	h2 = murmur3Fmix64(h2)
+
+	h1 += h2
+	h2 += h1
+	return h1, h2
+}
+
+func murmur3Fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+func (m *murmur3_128) Sum(b []byte) []byte {
+	h1, h2 := m.Sum128()
+
+	var d [16]byte
+	binary.LittleEndian.PutUint64(d[:], h1)
+	binary.LittleEndian.PutUint64(d[8:], h2)
+	return append(b, d[:]...)
+}
+
+// murmur3_64 is the x64 64-bit variant: the first half of the x64
+// 128-bit hash.
+type murmur3_64 struct {
+	*murmur3_128
+}
+
+func newMurmur3_64(seed uint32) murmur3_64 {
+	return murmur3_64{newMurmur3_128(seed)}
+}
+
+func (m murmur3_64) Size() int { return 8 }
+
+func (m murmur3_64) Sum(b []byte) []byte {
+	h1, _ := m.Sum128()
+
+	var d [8]byte
+	binary.LittleEndian.PutUint64(d[:], h1)
+	return append(b, d[:]...)
+}
@@ -0,0 +1,87 @@
+package multihash
+
+import (
+	"hash"
+	"math"
+)
+
+// nonCryptographicHashes are the built-in hash functions designed for
+// speed rather than security, for example for hash tables, sharding
+// or bloom filters. Anyone can craft collisions for them.
+var nonCryptographicHashes = map[uint64]bool{
+	MURMUR3_X64_64:  true,
+	MURMUR3_32:      true,
+	MURMUR3_X64_128: true,
+	XXH_32:          true,
+	XXH_64:          true,
+	XXH3_64:         true,
+	XXH3_128:        true,
+}
+
+// Cryptographic reports whether the hash function of a code is meant
+// to be a cryptographic hash function, even a broken one like md5. It
+// is false for unknown codes, and for fast hash functions such as
+// murmur3 or xxHash, which must never be used for content addressing
+// untrusted data.
+func Cryptographic(code uint64) bool {
+	if nonCryptographicHashes[code] {
+		return false
+	}
+
+	_, ok := lookupCode(code)
+	return ok
+}
+
+// NewSeededHasher returns a Hasher for one of the murmur3 or xxHash
+// codes with the given seed. The seed of the murmur3 codes and of
+// XXH_32 must fit in 32 bits. Sum and NewHasher use a seed of zero.
+func NewSeededHasher(code uint64, length int, seed uint64) (Hasher, error) {
+	if !nonCryptographicHashes[code] {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	_, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := newSeededHash(code, seed)
+	if err != nil {
+		return nil, err
+	}
+	return newMhHasher(code, length, h)
+}
+
+func newSeededHash(code uint64, seed uint64) (hash.Hash, error) {
+	switch code {
+	case XXH_64:
+		return newXXH64(seed), nil
+	case XXH3_64:
+		return newXXH3(8, seed), nil
+	case XXH3_128:
+		return newXXH3(16, seed), nil
+	}
+
+	if seed > math.MaxUint32 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	switch code {
+	case MURMUR3_32:
+		return newMurmur3_32(uint32(seed)), nil
+	case MURMUR3_X64_64:
+		return newMurmur3_64(uint32(seed)), nil
+	case MURMUR3_X64_128:
+		return newMurmur3_128(uint32(seed)), nil
+	default:
+		return newXXH32(uint32(seed)), nil
+	}
+}
+
+// nonCryptographicHashFunc returns the HashFunc of a code of
+// nonCryptographicHashes, with a seed of zero.
+func nonCryptographicHashFunc(code uint64) HashFunc {
+	return func(int) (hash.Hash, error) {
+		return newSeededHash(code, 0)
+	}
+}
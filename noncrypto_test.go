@@ -0,0 +1,218 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestSeededHashes(t *testing.T) {
+	// computed with the reference implementations
+	cases := []struct {
+		code uint64
+		seed uint64
+		data []byte
+		hex  string
+	}{
+		{MURMUR3_32, 0, []byte("beep boop"), "243ddb9e"},
+		{MURMUR3_32, 1, []byte(""), "b7284e51"},
+		{MURMUR3_32, 0xffffffff, []byte(""), "396ff181"},
+		{MURMUR3_X64_128, 0, []byte(""), "00000000000000000000000000000000"},
+		{MURMUR3_X64_128, 42, []byte(""), "23851bfa7da72af0b9cb11da106601d1"},
+		{MURMUR3_X64_64, 0, []byte(""), "0000000000000000"},
+		{MURMUR3_X64_64, 42, []byte(""), "23851bfa7da72af0"},
+		{XXH_32, 0, []byte(""), "02cc5d05"},
+		{XXH_32, 42, []byte(""), "d5be6eb8"},
+		{XXH_64, 0, []byte(""), "ef46db3751d8e999"},
+		{XXH3_64, 0, []byte(""), "2d06800538d394c2"},
+		{XXH3_64, 42, []byte(""), "b029411ff43d84d2"},
+		{XXH3_128, 0, []byte(""), "99aa06d3014798d86001c324468d497f"},
+		{XXH3_128, 42, []byte(""), "16c20acd33f7af2f3c1d09e9fe249164"},
+		{MURMUR3_X64_128, 0, []byte("a"), "897859f6655555855a890e51483ab5e6"},
+		{MURMUR3_X64_128, 42, []byte("a"), "b026f6fda49c2528152bf82591caeb25"},
+		{MURMUR3_X64_64, 0, []byte("a"), "897859f665555585"},
+		{MURMUR3_X64_64, 42, []byte("a"), "b026f6fda49c2528"},
+		{XXH_32, 0, []byte("a"), "550d7456"},
+		{XXH_32, 42, []byte("a"), "4bedc2db"},
+		{XXH_64, 0, []byte("a"), "d24ec4f1a98c6e5b"},
+		{XXH3_64, 0, []byte("a"), "e6c632b61e964e1f"},
+		{XXH3_64, 42, []byte("a"), "4c437dd47f0716f4"},
+		{XXH3_128, 0, []byte("a"), "a96faf705af16834e6c632b61e964e1f"},
+		{XXH3_128, 42, []byte("a"), "28df5945863b24b74c437dd47f0716f4"},
+		{MURMUR3_X64_128, 0, []byte("beep boop"), "75f088bf5b9cfeacad0e436404dfc4c0"},
+		{MURMUR3_X64_128, 42, []byte("beep boop"), "e07e2429c3b89b8154b40308f3dd2ee7"},
+		{MURMUR3_X64_64, 0, []byte("beep boop"), "75f088bf5b9cfeac"},
+		{MURMUR3_X64_64, 42, []byte("beep boop"), "e07e2429c3b89b81"},
+		{XXH_32, 0, []byte("beep boop"), "5387d03c"},
+		{XXH_32, 42, []byte("beep boop"), "2f941d8e"},
+		{XXH_64, 0, []byte("beep boop"), "53bf30adf769d4b3"},
+		{XXH3_64, 0, []byte("beep boop"), "6bdf4240b98290a4"},
+		{XXH3_64, 42, []byte("beep boop"), "2ce214e3acd50b4e"},
+		{XXH3_128, 0, []byte("beep boop"), "3d8b7da01d73137ad7fb68d5b1250cc8"},
+		{XXH3_128, 42, []byte("beep boop"), "b87e3b0439ed5d11569ffad098aba020"},
+		{MURMUR3_X64_128, 0, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "3a5effb45e6f59f2c0946d2e37b99639"},
+		{MURMUR3_X64_128, 42, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "6f5e5f0e8555e1ecf730736f26e8bc7c"},
+		{MURMUR3_X64_64, 0, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "3a5effb45e6f59f2"},
+		{MURMUR3_X64_64, 42, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "6f5e5f0e8555e1ec"},
+		{XXH_32, 0, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "42ae804d"},
+		{XXH_32, 42, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "4be6b596"},
+		{XXH_64, 0, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "64f23ecf1609b766"},
+		{XXH3_64, 0, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "1173f34a884ae63c"},
+		{XXH3_64, 42, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "0c7558fa0e26a0f5"},
+		{XXH3_128, 0, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "6d54bb4e6bb9d97a10f98e19d9422276"},
+		{XXH3_128, 42, []byte("abcdefghijklmnopqrstuvwxyz0123456789"), "b7a9c0d1f454a96551978224c610af5e"},
+		{MURMUR3_X64_128, 0, patternInput(16), "303f9091b524494445e82f76566490ab"},
+		{MURMUR3_X64_128, 42, patternInput(16), "29de86174ffab552401e4260c55b4d3c"},
+		{XXH_32, 0, patternInput(16), "b72837f4"},
+		{XXH_32, 42, patternInput(16), "2883aa82"},
+		{XXH_64, 0, patternInput(16), "44b6ef2fb84169f7"},
+		{XXH3_64, 0, patternInput(16), "8355e3a6f61770db"},
+		{XXH3_64, 42, patternInput(16), "74891a34d3fff0a9"},
+		{XXH3_128, 0, patternInput(16), "72950631827607e2842812cc870dcae2"},
+		{XXH3_128, 42, patternInput(16), "6a60d699e874c2188397ff66a715007f"},
+		{MURMUR3_X64_128, 0, patternInput(17), "0ec2e79f0ff4765c24a8da9e6b025fc1"},
+		{MURMUR3_X64_128, 42, patternInput(17), "fba12a339df8088f6cdf19cd98b42b59"},
+		{XXH_32, 0, patternInput(17), "7c77adc2"},
+		{XXH_32, 42, patternInput(17), "4dd5cea4"},
+		{XXH_64, 0, patternInput(17), "5603e60c527599b6"},
+		{XXH3_64, 0, patternInput(17), "9ef341a99de37328"},
+		{XXH3_64, 42, patternInput(17), "2668e3977d451c23"},
+		{XXH3_128, 0, patternInput(17), "685bc458b37d057fc06e233df7729217"},
+		{XXH3_128, 42, patternInput(17), "e218637beef5edb4ff1759db8e15f1ad"},
+		{MURMUR3_X64_128, 0, patternInput(128), "537ef1a53b4dd7952e808db6afa7a0ab"},
+		{MURMUR3_X64_128, 42, patternInput(128), "0bc6dec1dc3a7cca1f4102913fa289c6"},
+		{XXH_32, 0, patternInput(128), "6d6194b7"},
+		{XXH_32, 42, patternInput(128), "b99739af"},
+		{XXH_64, 0, patternInput(128), "7a7fe14647b9ab92"},
+		{XXH3_64, 0, patternInput(128), "85c6174c7ff4c46b"},
+		{XXH3_64, 42, patternInput(128), "a7f863935f4a4028"},
+		{XXH3_128, 0, patternInput(128), "14792fc3af88dc6c05321a0b64d67b41"},
+		{XXH3_128, 42, patternInput(128), "2cfa5536407c26cecd7065b1aea2e4e9"},
+		{MURMUR3_X64_128, 0, patternInput(129), "e5af6fdc48d6675388233ca8627cfe63"},
+		{MURMUR3_X64_128, 42, patternInput(129), "dcac9d99a701299df87e869ca17dfb16"},
+		{XXH_32, 0, patternInput(129), "6572cb97"},
+		{XXH_32, 42, patternInput(129), "63aeba36"},
+		{XXH_64, 0, patternInput(129), "0ba25dfd6e891fcf"},
+		{XXH3_64, 0, patternInput(129), "ec7642b431ba3e5a"},
+		{XXH3_64, 42, patternInput(129), "82b80bdd4ac29db5"},
+		{XXH3_128, 0, patternInput(129), "dd5e74ac6b45f54ebc30b63382b09a3b"},
+		{XXH3_128, 42, patternInput(129), "9e41bfeaf492d7e540b91a40e61888b9"},
+		{MURMUR3_X64_128, 0, patternInput(240), "46f2b0af1279b60c5b2cd7ebb331668a"},
+		{MURMUR3_X64_128, 42, patternInput(240), "868184283d90f3eaf1ac139697c11dd5"},
+		{XXH_32, 0, patternInput(240), "1fd0fbb0"},
+		{XXH_32, 42, patternInput(240), "fec93e7d"},
+		{XXH_64, 0, patternInput(240), "012947f0da6a27b1"},
+		{XXH3_64, 0, patternInput(240), "375a384d957fe865"},
+		{XXH3_64, 42, patternInput(240), "4c023d24e6a84d31"},
+		{XXH3_128, 0, patternInput(240), "65b5be86da5540e7c92b68e16f83bbb6"},
+		{XXH3_128, 42, patternInput(240), "8e76dd8a173ddbc5ba3788ebe65051f7"},
+		{MURMUR3_X64_128, 0, patternInput(241), "0f6d68a60d5eb3423b69c1b32642a09e"},
+		{MURMUR3_X64_128, 42, patternInput(241), "7c0f3a40e4013a1ba6abc3e25c7b7fbc"},
+		{XXH_32, 0, patternInput(241), "5b9a61e5"},
+		{XXH_32, 42, patternInput(241), "714b1694"},
+		{XXH_64, 0, patternInput(241), "8d643f23bf2808e1"},
+		{XXH3_64, 0, patternInput(241), "02e8cd95421c6d02"},
+		{XXH3_64, 42, patternInput(241), "26e3d358d4e0a1d6"},
+		{XXH3_128, 0, patternInput(241), "1da1cb61bcb8a2a102e8cd95421c6d02"},
+		{XXH3_128, 42, patternInput(241), "d591e680c65b77ff26e3d358d4e0a1d6"},
+		{MURMUR3_X64_128, 0, patternInput(1024), "47db36fd76caf30a593eedf2ed69b8e4"},
+		{MURMUR3_X64_128, 42, patternInput(1024), "c0028ae076bacddb30aa5aa5bd0f0d0e"},
+		{XXH_32, 0, patternInput(1024), "69dd7c7e"},
+		{XXH_32, 42, patternInput(1024), "48015fe7"},
+		{XXH_64, 0, patternInput(1024), "138e26c65048ce29"},
+		{XXH3_64, 0, patternInput(1024), "e5d78bafa45b2aa5"},
+		{XXH3_64, 42, patternInput(1024), "b0e3ba3ff9ba14fd"},
+		{XXH3_128, 0, patternInput(1024), "d0ac1f7b93bf57b9e5d78bafa45b2aa5"},
+		{XXH3_128, 42, patternInput(1024), "832903ce8ee6dbb5b0e3ba3ff9ba14fd"},
+		{MURMUR3_X64_128, 0, patternInput(3000), "8fa06396123ce65524b3fac5d7864a8d"},
+		{MURMUR3_X64_128, 42, patternInput(3000), "8fedce8b2559da4fa3b4264d820547ca"},
+		{XXH_32, 0, patternInput(3000), "728ce836"},
+		{XXH_32, 42, patternInput(3000), "52ccab79"},
+		{XXH_64, 0, patternInput(3000), "0bf839809bf7d3b8"},
+		{XXH3_64, 0, patternInput(3000), "1b846747012c24aa"},
+		{XXH3_64, 42, patternInput(3000), "28483a440b8e9b67"},
+		{XXH3_128, 0, patternInput(3000), "d324b9e72fa9fb271b846747012c24aa"},
+		{XXH3_128, 42, patternInput(3000), "57d3cdb4adecc20528483a440b8e9b67"},
+	}
+
+	for i, tc := range cases {
+		h, err := NewSeededHasher(tc.code, -1, tc.seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// write in uneven pieces, after a reset
+		h.Write([]byte("garbage"))
+		h.Reset()
+		for p := tc.data; len(p) > 0; {
+			n := 100
+			if n > len(p) {
+				n = len(p)
+			}
+			h.Write(p[:n])
+			p = p[n:]
+		}
+
+		if d := hex.EncodeToString(h.Sum(nil)); d != tc.hex {
+			t.Errorf("%d: %s with seed %d of %d bytes: got %s", i, Codes[tc.code], tc.seed, len(tc.data), d)
+		}
+
+		if tc.seed == 0 {
+			m, err := Sum(tc.data, tc.code, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dm, _ := Decode(m); !bytes.Equal(dm.Digest, h.Sum(nil)) {
+				t.Errorf("%d: Sum differs from the streamed digest", i)
+			}
+		}
+	}
+}
+
+func TestXXH3Streaming(t *testing.T) {
+	// every split of inputs around the buffer and block sizes
+	data := patternInput(4 * 1024)
+	for _, n := range []int{255, 256, 257, 1023, 1024, 1025, 2048, 2049, 4096} {
+		ref, _ := NewSeededHasher(XXH3_128, -1, 7)
+		ref.Write(data[:n])
+		want := ref.Sum(nil)
+
+		for split := 0; split <= n; split += 61 {
+			h, _ := NewSeededHasher(XXH3_128, -1, 7)
+			h.Write(data[:split])
+			h.Write(data[split:n])
+			if !bytes.Equal(h.Sum(nil), want) {
+				t.Errorf("%d bytes split at %d: wrong digest", n, split)
+			}
+		}
+	}
+}
+
+func TestCryptographic(t *testing.T) {
+	for _, c := range []uint64{SHA2_256, MD5, SHA1, BLAKE3} {
+		if !Cryptographic(c) {
+			t.Errorf("0x%x should be cryptographic", c)
+		}
+	}
+	for _, c := range []uint64{MURMUR3_X64_64, MURMUR3_32, MURMUR3_X64_128, XXH_64, XXH3_128, 0xdeadbeef} {
+		if Cryptographic(c) {
+			t.Errorf("0x%x should not be cryptographic", c)
+		}
+		if CollisionResistant(c) {
+			t.Errorf("0x%x should not be collision resistant", c)
+		}
+	}
+}
+
+func TestNewSeededHasherErrors(t *testing.T) {
+	if _, err := NewSeededHasher(SHA2_256, -1, 0); !errors.Is(err, ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+	if _, err := NewSeededHasher(XXH_32, -1, 1<<32); !errors.Is(err, ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+	if _, err := NewSeededHasher(XXH_64, 9, 0); !errors.Is(err, ErrLenNotSupported) {
+		t.Error("expected ErrLenNotSupported, got: ", err)
+	}
+}
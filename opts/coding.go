@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	mh "github.com/multiformats/go-multihash"
-	base58 "gx/ipfs/QmT8rehPR3F6bmwL6zjUN8XpiDBFFpMP2myPdC6ApsWfJf/go-base58"
 )
 
 func Decode(encoding, digest string) (mh.Multihash, error) {
@@ -16,7 +15,11 @@ func Decode(encoding, digest string) (mh.Multihash, error) {
 	case "hex":
 		return hex.DecodeString(digest)
 	case "base58":
-		return base58.Decode(digest), nil
+		b, err := mh.DecodeBase58(digest)
+		if err != nil {
+			return nil, err
+		}
+		return mh.Cast(b)
 	case "base64":
 		return base64.StdEncoding.DecodeString(digest)
 	default:
@@ -31,7 +34,7 @@ func Encode(encoding string, hash mh.Multihash) (string, error) {
 	case "hex":
 		return hex.EncodeToString(hash), nil
 	case "base58":
-		return base58.Encode(hash), nil
+		return mh.EncodeBase58(hash), nil
 	case "base64":
 		return base64.StdEncoding.EncodeToString(hash), nil
 	default:
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"strings"
 
 	mh "github.com/multiformats/go-multihash"
@@ -25,6 +26,7 @@ type Options struct {
 	Algorithm     string
 	AlgorithmCode uint64
 	Length        int
+	Git           bool
 
 	fs *flag.FlagSet
 }
@@ -35,7 +37,7 @@ var FlagValues = struct {
 	Algorithms []string
 }{
 	Encodings:  []string{"raw", "hex", "base58", "base64"},
-	Algorithms: []string{"sha1", "sha2-256", "sha2-512", "sha3"},
+	Algorithms: []string{"sha1", "sha2-224", "sha2-256", "sha2-384", "sha2-512", "sha2-512-224", "sha2-512-256", "sha3"},
 }
 
 // SetupFlags adds multihash related options to given flagset.
@@ -54,6 +56,9 @@ func SetupFlags(f *flag.FlagSet) *Options {
 	lengthStr := "checksums length in bits (truncate). -1 is default"
 	f.IntVar(&o.Length, "length", -1, lengthStr)
 	f.IntVar(&o.Length, "l", -1, lengthStr+" (shorthand)")
+
+	gitStr := "hash as a git object ID (a blob, or a tree for a directory). needs sha1 or sha2-256"
+	f.BoolVar(&o.Git, "git", false, gitStr)
 	return o
 }
 
@@ -77,19 +82,29 @@ func (o *Options) ParseError() error {
 	}
 
 	var found bool
-	o.AlgorithmCode, found = mh.Names[o.Algorithm]
+	o.AlgorithmCode, found = mh.LookupCode(o.Algorithm)
 	if !found {
 		return fmt.Errorf("algorithm '%s' not found (lib error, pls report).", o.Algorithm)
 	}
 
+	if o.Git {
+		if o.AlgorithmCode != mh.SHA1 && o.AlgorithmCode != mh.SHA2_256 {
+			return fmt.Errorf("git object IDs need sha1 or sha2-256, not '%s'", o.Algorithm)
+		}
+		if o.Length >= 0 {
+			return fmt.Errorf("git object IDs cannot be truncated")
+		}
+	}
+
 	if o.Length >= 0 {
 		if o.Length%8 != 0 {
 			return fmt.Errorf("length must be multiple of 8")
 		}
 		o.Length = o.Length / 8
 
-		if o.Length > mh.DefaultLengths[o.AlgorithmCode] {
-			o.Length = mh.DefaultLengths[o.AlgorithmCode]
+		dl, _ := mh.LookupDefaultLength(o.AlgorithmCode)
+		if o.Length > dl {
+			o.Length = dl
 		}
 	}
 	return nil
@@ -121,11 +136,65 @@ func (o *Options) Check(r io.Reader, h1 mh.Multihash) error {
 }
 
 // Multihash reads all the data in r and calculates its multihash.
+// The data is streamed through the hash function, so r may be
+// arbitrarily large. Git blobs start with their length: it is that of
+// r if r is a regular file or has a Len method, and otherwise r is
+// first copied to a temporary file to learn it.
 func (o *Options) Multihash(r io.Reader) (mh.Multihash, error) {
-	b, err := ioutil.ReadAll(r)
+	if o.Git {
+		return o.gitBlob(r)
+	}
+
+	h, err := mh.NewHasher(o.AlgorithmCode, o.Length)
 	if err != nil {
 		return nil, err
 	}
 
-	return mh.Sum(b, o.AlgorithmCode, o.Length)
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+
+	return h.Multihash()
+}
+
+func (o *Options) gitBlob(r io.Reader) (mh.Multihash, error) {
+	size, ok := readerSize(r)
+	if !ok {
+		f, err := ioutil.TempFile("", "multihash-git")
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		if size, err = io.Copy(f, r); err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		r = f
+	}
+
+	return mh.SumGitObjectReader(mh.GitBlob, r, size, o.AlgorithmCode)
+}
+
+// readerSize returns the number of bytes left in r, if it is known
+// without reading them.
+func readerSize(r io.Reader) (int64, bool) {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case *os.File:
+		fi, err := r.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return 0, false
+		}
+		off, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return fi.Size() - off, true
+	}
+	return 0, false
 }
@@ -0,0 +1,159 @@
+package multihash
+
+import (
+	"errors"
+	"hash"
+	"sync"
+)
+
+// errors
+var (
+	// ErrAlreadyRegistered is returned by Register when the code or
+	// the name of a hash function is already taken.
+	ErrAlreadyRegistered = errors.New("multihash code or name already registered")
+	// ErrInvalidRegistration is returned by Register for empty names,
+	// negative lengths or nil functions.
+	ErrInvalidRegistration = errors.New("invalid hash function registration")
+)
+
+// HashFunc returns a new hash.Hash for a multihash code. The length
+// argument is the digest length (in bytes) requested by the caller.
+// Hash functions with a fixed output may ignore it: the Hasher takes
+// care of truncating their digests.
+type HashFunc func(length int) (hash.Hash, error)
+
+// KeyedHashFunc returns a new keyed hash.Hash (a MAC) for a multihash
+// code. The length argument is as for HashFunc.
+type KeyedHashFunc func(key []byte, length int) (hash.Hash, error)
+
+type hashFunction struct {
+	code          uint64
+	name          string
+	defaultLength int
+	newHash       HashFunc
+	newKeyedHash  KeyedHashFunc
+}
+
+// registry holds all the hash functions known to this package.
+var registry = struct {
+	sync.RWMutex
+	codes map[uint64]*hashFunction
+	names map[string]*hashFunction
+}{
+	codes: make(map[uint64]*hashFunction),
+	names: make(map[string]*hashFunction),
+}
+
+// Register makes a hash function available under the given code and
+// name, so that it can be used with Sum, NewHasher, EncodeName and
+// friends. It returns ErrAlreadyRegistered if either the code or the
+// name is in use. It is safe to call Register concurrently with any
+// other function in this package.
+func Register(code uint64, name string, defaultLength int, newHash HashFunc) error {
+	if newHash == nil {
+		return ErrInvalidRegistration
+	}
+
+	return register(&hashFunction{
+		code:          code,
+		name:          name,
+		defaultLength: defaultLength,
+		newHash:       newHash,
+	})
+}
+
+// RegisterKeyed is like Register, for keyed hash functions. These can
+// only be used through NewKeyedHasher and SumKeyed.
+func RegisterKeyed(code uint64, name string, defaultLength int, newKeyedHash KeyedHashFunc) error {
+	if newKeyedHash == nil {
+		return ErrInvalidRegistration
+	}
+
+	return register(&hashFunction{
+		code:          code,
+		name:          name,
+		defaultLength: defaultLength,
+		newKeyedHash:  newKeyedHash,
+	})
+}
+
+func register(f *hashFunction) error {
+	if f.name == "" || f.defaultLength < 0 {
+		return ErrInvalidRegistration
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.codes[f.code]; ok {
+		return ErrAlreadyRegistered
+	}
+	if _, ok := registry.names[f.name]; ok {
+		return ErrAlreadyRegistered
+	}
+
+	registry.codes[f.code] = f
+	registry.names[f.name] = f
+	return nil
+}
+
+// registerAlias adds an alternative name for a registered code.
+func registerAlias(name string, code uint64) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.names[name] = registry.codes[code]
+}
+
+// LookupName returns the name registered for a code.
+func LookupName(code uint64) (string, bool) {
+	f, ok := lookupCode(code)
+	if !ok {
+		return "", false
+	}
+	return f.name, true
+}
+
+// LookupCode returns the code registered for a name.
+func LookupCode(name string) (uint64, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	f, ok := registry.names[name]
+	if !ok {
+		return 0, false
+	}
+	return f.code, true
+}
+
+// LookupDefaultLength returns the default digest length for a code.
+func LookupDefaultLength(code uint64) (int, bool) {
+	f, ok := lookupCode(code)
+	if !ok {
+		return 0, false
+	}
+	return f.defaultLength, true
+}
+
+// CollisionResistant reports whether the hash function of a code is
+// believed to be collision resistant, and thus fit for security
+// decisions. It is false for unknown codes, for legacy hash
+// functions such as md5 or sha1, and for non-cryptographic ones such
+// as murmur3 (see Cryptographic). Hash functions added with Register
+// are assumed to be collision resistant.
+func CollisionResistant(code uint64) bool {
+	if weakHashes[code] || nonCryptographicHashes[code] {
+		return false
+	}
+
+	_, ok := lookupCode(code)
+	return ok
+}
+
+func lookupCode(code uint64) (*hashFunction, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	f, ok := registry.codes[code]
+	return f, ok
+}
@@ -0,0 +1,138 @@
+package multihash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"sync"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	const code = 0x300001
+	const name = "test-sha2-256-reg"
+
+	err := Register(code, name, 32, func(int) (hash.Hash, error) {
+		return sha256.New(), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ValidCode(code) {
+		t.Error("registered code should be valid")
+	}
+	if n, ok := LookupName(code); !ok || n != name {
+		t.Error("LookupName failed", n)
+	}
+	if c, ok := LookupCode(name); !ok || c != code {
+		t.Error("LookupCode failed", c)
+	}
+	if l, ok := LookupDefaultLength(code); !ok || l != 32 {
+		t.Error("LookupDefaultLength failed", l)
+	}
+
+	m, err := Sum([]byte("foo"), code, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dm, err := Decode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dm.Name != name {
+		t.Error("decoded name mismatch: ", dm.Name, name)
+	}
+
+	ref, _ := Sum([]byte("foo"), SHA2_256, -1)
+	dref, _ := Decode(ref)
+	if !bytes.Equal(dm.Digest, dref.Digest) {
+		t.Error("registered hash function gave wrong digest")
+	}
+
+	enc, err := EncodeName(dm.Digest, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc, m) {
+		t.Error("EncodeName mismatch")
+	}
+
+	newHash := func(int) (hash.Hash, error) { return sha256.New(), nil }
+	if err := Register(code, "test-other-name", 32, newHash); err != ErrAlreadyRegistered {
+		t.Error("expected ErrAlreadyRegistered for duplicate code, got: ", err)
+	}
+	if err := Register(0x300002, name, 32, newHash); err != ErrAlreadyRegistered {
+		t.Error("expected ErrAlreadyRegistered for duplicate name, got: ", err)
+	}
+	if err := Register(SHA1, "sha1-again", 20, newHash); err != ErrAlreadyRegistered {
+		t.Error("expected ErrAlreadyRegistered for built-in code, got: ", err)
+	}
+	if err := Register(0x300003, "test-nil", 32, nil); err == nil {
+		t.Error("expected error registering nil HashFunc")
+	}
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	newHash := func(int) (hash.Hash, error) { return sha256.New(), nil }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			code := uint64(0x310000 + i)
+			if err := Register(code, fmt.Sprintf("test-concurrent-%d", i), 32, newHash); err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := Sum([]byte("foo"), code, -1); err != nil {
+				t.Error(err)
+			}
+			if _, err := Sum([]byte("foo"), SHA2_256, -1); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestLookupAlias(t *testing.T) {
+	c, ok := LookupCode("sha3")
+	if !ok || c != SHA3_512 {
+		t.Error("sha3 alias should resolve to sha3-512")
+	}
+	if n, _ := LookupName(SHA3_512); n != "sha3-512" {
+		t.Error("sha3-512 should keep its canonical name, got: ", n)
+	}
+
+	// the old name of 0x22, before it became murmur3-x64-64
+	if c, ok := LookupCode("murmur3"); !ok || c != MURMUR3_X64_64 {
+		t.Error("murmur3 alias should resolve to murmur3-x64-64")
+	}
+	if n, _ := LookupName(MURMUR3_X64_64); n != "murmur3-x64-64" {
+		t.Error("murmur3-x64-64 should keep its canonical name, got: ", n)
+	}
+}
+
+func TestEncodeNameUnknown(t *testing.T) {
+	if _, err := EncodeName([]byte("foo"), "not-a-hash"); !errors.Is(err, ErrUnknownCode) {
+		t.Error("expected ErrUnknownCode, got: ", err)
+	}
+}
+
+func TestCollisionResistant(t *testing.T) {
+	for _, c := range []uint64{SHA2_256, SHA3_512, BLAKE2B_MAX, BLAKE3, 0x300001} {
+		if !CollisionResistant(c) {
+			t.Errorf("0x%x should be collision resistant", c)
+		}
+	}
+	for _, c := range []uint64{SHA1, MD4, MD5, RIPEMD_160, HASH160, MURMUR3_X64_64, 0xdeadbeef} {
+		if CollisionResistant(c) {
+			t.Errorf("0x%x should not be collision resistant", c)
+		}
+	}
+}
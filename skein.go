@@ -0,0 +1,224 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// This is an implementation of the Skein 1.3 hash function for the
+// 256, 512 and 1024 bit state sizes with arbitrary output lengths.
+// It lives in-tree so that skein multihashes can be computed
+// incrementally through the Hasher interface.
+
+const (
+	skeinTypeCfg = 4
+	skeinTypeMsg = 48
+	skeinTypeOut = 63
+
+	skeinFirst = uint64(1) << 62
+	skeinFinal = uint64(1) << 63
+
+	skeinC240 = 0x1BD11BDAA9FC1A22
+)
+
+var skeinRot256 = [8][2]uint{
+	{14, 16}, {52, 57}, {23, 40}, {5, 37},
+	{25, 33}, {46, 12}, {58, 22}, {32, 32},
+}
+
+var skeinRot512 = [8][4]uint{
+	{46, 36, 19, 37}, {33, 27, 14, 42}, {17, 49, 36, 39}, {44, 9, 54, 56},
+	{39, 30, 34, 24}, {13, 50, 10, 17}, {25, 29, 39, 43}, {8, 35, 56, 22},
+}
+
+var skeinRot1024 = [8][8]uint{
+	{24, 13, 8, 47, 8, 17, 22, 37},
+	{38, 19, 10, 55, 49, 18, 23, 52},
+	{33, 4, 51, 13, 34, 41, 59, 17},
+	{5, 20, 48, 41, 47, 28, 16, 25},
+	{41, 9, 37, 31, 12, 47, 44, 30},
+	{16, 34, 56, 51, 4, 53, 42, 41},
+	{31, 44, 47, 46, 19, 42, 44, 25},
+	{9, 48, 35, 52, 23, 31, 37, 20},
+}
+
+var skeinPerm256 = []int{0, 3, 2, 1}
+var skeinPerm512 = []int{2, 1, 4, 7, 6, 5, 0, 3}
+var skeinPerm1024 = []int{0, 9, 2, 13, 6, 11, 4, 15, 10, 7, 12, 3, 14, 5, 8, 1}
+
+func rotl64(x uint64, n uint) uint64 {
+	return x<<n | x>>(64-n)
+}
+
+// threefish encrypts the block in v (in place) with the given key and tweak.
+func threefish(v, key []uint64, t0, t1 uint64) {
+	nw := len(v)
+	k := make([]uint64, nw+1)
+	copy(k, key)
+	k[nw] = skeinC240
+	for i := 0; i < nw; i++ {
+		k[nw] ^= k[i]
+	}
+	t := [3]uint64{t0, t1, t0 ^ t1}
+
+	rounds := 72
+	perm := skeinPerm256
+	if nw == 8 {
+		perm = skeinPerm512
+	} else if nw == 16 {
+		rounds = 80
+		perm = skeinPerm1024
+	}
+
+	inject := func(s int) {
+		for i := 0; i < nw; i++ {
+			v[i] += k[(s+i)%(nw+1)]
+		}
+		v[nw-3] += t[s%3]
+		v[nw-2] += t[(s+1)%3]
+		v[nw-1] += uint64(s)
+	}
+
+	tmp := make([]uint64, nw)
+	for d := 0; d < rounds; d++ {
+		if d%4 == 0 {
+			inject(d / 4)
+		}
+		for j := 0; j < nw/2; j++ {
+			var r uint
+			switch nw {
+			case 4:
+				r = skeinRot256[d%8][j]
+			case 8:
+				r = skeinRot512[d%8][j]
+			default:
+				r = skeinRot1024[d%8][j]
+			}
+			v[2*j] += v[2*j+1]
+			v[2*j+1] = rotl64(v[2*j+1], r) ^ v[2*j]
+		}
+		for i := range tmp {
+			tmp[i] = v[perm[i]]
+		}
+		copy(v, tmp)
+	}
+	inject(rounds / 4)
+}
+
+// skein is a hash.Hash computing Skein digests.
+type skein struct {
+	bsize  int // state (and block) size in bytes
+	outLen int // output length in bytes
+
+	iv    []uint64
+	chain []uint64
+	buf   []byte
+	nbuf  int
+	pos   uint64
+	first bool
+}
+
+// newSkein returns a Skein hash with a state size of stateBits
+// and a digest of outLen bytes.
+func newSkein(stateBits, outLen int) hash.Hash {
+	s := &skein{
+		bsize:  stateBits / 8,
+		outLen: outLen,
+	}
+	nw := s.bsize / 8
+	s.chain = make([]uint64, nw)
+	s.buf = make([]byte, s.bsize)
+
+	// compute the chaining value after the configuration block
+	cfg := make([]byte, s.bsize)
+	copy(cfg, []byte("SHA3"))
+	binary.LittleEndian.PutUint16(cfg[4:], 1)
+	binary.LittleEndian.PutUint64(cfg[8:], uint64(outLen)*8)
+	s.ubi(cfg, 32, skeinTypeCfg<<56|skeinFirst|skeinFinal)
+
+	s.iv = make([]uint64, nw)
+	copy(s.iv, s.chain)
+	s.Reset()
+	return s
+}
+
+// ubi processes a single block with the given position and tweak flags.
+func (s *skein) ubi(block []byte, pos, flags uint64) {
+	nw := len(s.chain)
+	m := make([]uint64, nw)
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[i*8:])
+	}
+	v := make([]uint64, nw)
+	copy(v, m)
+	threefish(v, s.chain, pos, flags)
+	for i := range s.chain {
+		s.chain[i] = v[i] ^ m[i]
+	}
+}
+
+func (s *skein) Reset() {
+	copy(s.chain, s.iv)
+	s.nbuf = 0
+	s.pos = 0
+	s.first = true
+}
+
+func (s *skein) Size() int      { return s.outLen }
+func (s *skein) BlockSize() int { return s.bsize }
+
+func (s *skein) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// the last block has to be processed with the final flag,
+		// so a full buffer is only flushed once more data arrives.
+		if s.nbuf == s.bsize {
+			s.pos += uint64(s.bsize)
+			flags := uint64(skeinTypeMsg) << 56
+			if s.first {
+				flags |= skeinFirst
+				s.first = false
+			}
+			s.ubi(s.buf, s.pos, flags)
+			s.nbuf = 0
+		}
+		c := copy(s.buf[s.nbuf:], p)
+		s.nbuf += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (s *skein) Sum(in []byte) []byte {
+	// work on a copy so the caller can keep writing.
+	d := *s
+	d.chain = append([]uint64(nil), s.chain...)
+	d.buf = append([]byte(nil), s.buf...)
+
+	for i := d.nbuf; i < d.bsize; i++ {
+		d.buf[i] = 0
+	}
+	flags := uint64(skeinTypeMsg)<<56 | skeinFinal
+	if d.first {
+		flags |= skeinFirst
+	}
+	d.ubi(d.buf, d.pos+uint64(d.nbuf), flags)
+
+	g := append([]uint64(nil), d.chain...)
+	out := make([]byte, 0, d.outLen+d.bsize)
+	blk := make([]byte, d.bsize)
+	for i := uint64(0); len(out) < d.outLen; i++ {
+		for j := range blk {
+			blk[j] = 0
+		}
+		binary.LittleEndian.PutUint64(blk, i)
+		copy(d.chain, g)
+		d.ubi(blk, 8, skeinTypeOut<<56|skeinFirst|skeinFinal)
+		for _, w := range d.chain {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], w)
+			out = append(out, b[:]...)
+		}
+	}
+	return append(in, out[:d.outLen]...)
+}
@@ -0,0 +1,124 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// This is SM3, the hash function of GB/T 32905-2016 (and of
+// draft-shen-sm3-hash).
+
+var sm3IV = [8]uint32{
+	0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600,
+	0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e,
+}
+
+type sm3 struct {
+	v    [8]uint32
+	buf  [64]byte
+	nbuf int
+	n    uint64
+}
+
+func newSM3() *sm3 {
+	s := &sm3{}
+	s.Reset()
+	return s
+}
+
+func (s *sm3) Reset() {
+	*s = sm3{v: sm3IV}
+}
+
+func (s *sm3) Size() int      { return 32 }
+func (s *sm3) BlockSize() int { return 64 }
+
+func (s *sm3) Write(p []byte) (int, error) {
+	n := len(p)
+	s.n += uint64(n)
+
+	if s.nbuf > 0 {
+		c := copy(s.buf[s.nbuf:], p)
+		s.nbuf += c
+		p = p[c:]
+		if s.nbuf < 64 {
+			return n, nil
+		}
+		s.block(s.buf[:])
+		s.nbuf = 0
+	}
+
+	for ; len(p) >= 64; p = p[64:] {
+		s.block(p)
+	}
+	s.nbuf = copy(s.buf[:], p)
+	return n, nil
+}
+
+func sm3P0(x uint32) uint32 { return x ^ bits.RotateLeft32(x, 9) ^ bits.RotateLeft32(x, 17) }
+func sm3P1(x uint32) uint32 { return x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23) }
+
+func (s *sm3) block(p []byte) {
+	var w [68]uint32
+	for j := 0; j < 16; j++ {
+		w[j] = binary.BigEndian.Uint32(p[4*j:])
+	}
+	for j := 16; j < 68; j++ {
+		w[j] = sm3P1(w[j-16]^w[j-9]^bits.RotateLeft32(w[j-3], 15)) ^
+			bits.RotateLeft32(w[j-13], 7) ^ w[j-6]
+	}
+
+	a, b, c, d, e, f, g, h := s.v[0], s.v[1], s.v[2], s.v[3], s.v[4], s.v[5], s.v[6], s.v[7]
+	for j := 0; j < 64; j++ {
+		var t, ff, gg uint32
+		if j < 16 {
+			t = 0x79cc4519
+			ff = a ^ b ^ c
+			gg = e ^ f ^ g
+		} else {
+			t = 0x7a879d8a
+			ff = (a & b) | (a & c) | (b & c)
+			gg = (e & f) | (^e & g)
+		}
+
+		a12 := bits.RotateLeft32(a, 12)
+		ss1 := bits.RotateLeft32(a12+e+bits.RotateLeft32(t, j%32), 7)
+		ss2 := ss1 ^ a12
+		tt1 := ff + d + ss2 + (w[j] ^ w[j+4])
+		tt2 := gg + h + ss1 + w[j]
+
+		d = c
+		c = bits.RotateLeft32(b, 9)
+		b = a
+		a = tt1
+		h = g
+		g = bits.RotateLeft32(f, 19)
+		f = e
+		e = sm3P0(tt2)
+	}
+
+	s.v[0] ^= a
+	s.v[1] ^= b
+	s.v[2] ^= c
+	s.v[3] ^= d
+	s.v[4] ^= e
+	s.v[5] ^= f
+	s.v[6] ^= g
+	s.v[7] ^= h
+}
+
+func (s *sm3) Sum(b []byte) []byte {
+	d := *s
+
+	var pad [72]byte
+	pad[0] = 0x80
+	padLen := 1 + (55-int(d.n%64)+64)%64
+	binary.BigEndian.PutUint64(pad[padLen:], d.n*8)
+	d.Write(pad[:padLen+8])
+
+	var out [32]byte
+	for i, v := range d.v {
+		binary.BigEndian.PutUint32(out[4*i:], v)
+	}
+	return append(b, out[:]...)
+}
@@ -0,0 +1,18 @@
+package multihash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSM3(t *testing.T) {
+	// the first two are the examples of GB/T 32905-2016
+	testDigests(t, SM3_256, []digestTestCase{
+		{[]byte(""), "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b"},
+		{[]byte("abc"), "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{bytes.Repeat([]byte("abcd"), 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+		{patternInput(55), "a79cf9dcee3404abf7f769698201647fd9d3ff61d629d0f58bb4b5579a427db8"},
+		{patternInput(56), "62f7363b15f4de76dd925c493b9d6d00d4ba0ef2a1f334c1d0f13b293aeb40d1"},
+		{patternInput(1000), "b38fc481302b502c3f2f6608d060c47c5b6bd8fd65e148b7cd3af4988245f48a"},
+	})
+}
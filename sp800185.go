@@ -0,0 +1,249 @@
+package multihash
+
+import (
+	"hash"
+	"runtime"
+	"sync"
+)
+
+// This file has the cSHAKE, TupleHash and ParallelHash functions of
+// NIST SP 800-185. KMAC is in mac.go.
+
+// DefaultParallelHashBlockSize is the block size used by Sum and
+// NewHasher for the ParallelHash codes.
+const DefaultParallelHashBlockSize = 8192
+
+// NewCShakeHasher returns a Hasher for CSHAKE_128 or CSHAKE_256 with
+// the given customization string.
+func NewCShakeHasher(code uint64, length int, custom []byte) (Hasher, error) {
+	if code != CSHAKE_128 && code != CSHAKE_256 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	_, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkXOFLength(code, length); err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(code, length, newCShake(sp800185Rate(code), nil, custom, length))
+}
+
+// SumTuple computes the TupleHash of a tuple of byte strings, with
+// the given customization string. The code must be TUPLEHASH_128 or
+// TUPLEHASH_256. Unlike a concatenation, TupleHash tells apart
+// {"ab", "c"} from {"a", "bc"}. Sum and NewHasher hash the data as a
+// tuple of one element, which they have to buffer.
+func SumTuple(code uint64, length int, custom []byte, tuple ...[]byte) (Multihash, error) {
+	if code != TUPLEHASH_128 && code != TUPLEHASH_256 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	_, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkXOFLength(code, length); err != nil {
+		return nil, err
+	}
+
+	t := newTupleHash(code, custom, length)
+	for _, x := range tuple {
+		t.s.Write(encodeString(x))
+	}
+	return Encode(t.finish(&t.s), code)
+}
+
+// NewParallelHasher returns a Hasher for PARALLELHASH_128 or
+// PARALLELHASH_256 with the given block size and customization
+// string. Large writes are hashed on all CPUs.
+func NewParallelHasher(code uint64, length, blockSize int, custom []byte) (Hasher, error) {
+	if (code != PARALLELHASH_128 && code != PARALLELHASH_256) || blockSize <= 0 {
+		return nil, codeError(ErrInvalidParams, code)
+	}
+
+	_, length, err := lookupHashFunction(code, length)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkXOFLength(code, length); err != nil {
+		return nil, err
+	}
+
+	return newMhHasher(code, length, newParallelHash(code, blockSize, custom, length))
+}
+
+// sp800185HashFunc returns the HashFunc of the cSHAKE, TupleHash and
+// ParallelHash codes, without customization.
+func sp800185HashFunc(code uint64) HashFunc {
+	return func(length int) (hash.Hash, error) {
+		if err := checkXOFLength(code, length); err != nil {
+			return nil, err
+		}
+
+		switch code {
+		case CSHAKE_128, CSHAKE_256:
+			return newCShake(sp800185Rate(code), nil, nil, length), nil
+		case TUPLEHASH_128, TUPLEHASH_256:
+			return newTupleHash(code, nil, length), nil
+		default:
+			return newParallelHash(code, DefaultParallelHashBlockSize, nil, length), nil
+		}
+	}
+}
+
+// sp800185Rate returns the rate of the 128 or 256 bit variant of a
+// function: all their codes are odd for the 256 bit one.
+func sp800185Rate(code uint64) int {
+	if code&1 == 1 {
+		return 136
+	}
+	return 168
+}
+
+// tupleHash computes TupleHash of a tuple of one element, which is
+// buffered until Sum.
+type tupleHash struct {
+	s   sponge
+	buf []byte
+}
+
+func newTupleHash(code uint64, custom []byte, size int) *tupleHash {
+	return &tupleHash{s: *newCShake(sp800185Rate(code), []byte("TupleHash"), custom, size)}
+}
+
+func (t *tupleHash) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	return len(p), nil
+}
+
+func (t *tupleHash) Sum(in []byte) []byte {
+	s := t.s
+	s.Write(leftEncode(uint64(len(t.buf)) * 8))
+	s.Write(t.buf)
+	return append(in, t.finish(&s)...)
+}
+
+// finish pads s, which has absorbed the tuple, and squeezes it.
+func (t *tupleHash) finish(s *sponge) []byte {
+	s.Write(rightEncode(uint64(s.size) * 8))
+	return s.squeeze(s.size)
+}
+
+func (t *tupleHash) Reset() {
+	t.s.Reset()
+	t.buf = nil
+}
+
+func (t *tupleHash) Size() int      { return t.s.size }
+func (t *tupleHash) BlockSize() int { return t.s.rate }
+
+// parallelHashMinBlocks is the number of blocks a write must have for
+// them to be hashed in parallel.
+const parallelHashMinBlocks = 16
+
+// parallelHash computes ParallelHash. Each block is hashed with
+// SHAKE into a chaining value which is absorbed by the outer cSHAKE.
+type parallelHash struct {
+	outer  sponge
+	inner  sponge
+	b      int
+	nbuf   int
+	blocks uint64
+	cvLen  int
+}
+
+func newParallelHash(code uint64, blockSize int, custom []byte, size int) *parallelHash {
+	rate := sp800185Rate(code)
+	p := &parallelHash{
+		outer: *newCShake(rate, []byte("ParallelHash"), custom, size),
+		inner: *newCShake(rate, nil, nil, 0),
+		b:     blockSize,
+		cvLen: 200 - rate,
+	}
+	p.Reset()
+	return p
+}
+
+func (p *parallelHash) Reset() {
+	p.outer.Reset()
+	p.outer.Write(leftEncode(uint64(p.b)))
+	p.inner.Reset()
+	p.nbuf = 0
+	p.blocks = 0
+}
+
+func (p *parallelHash) Size() int      { return p.outer.size }
+func (p *parallelHash) BlockSize() int { return p.outer.rate }
+
+func (p *parallelHash) Write(buf []byte) (int, error) {
+	n := len(buf)
+	for len(buf) > 0 {
+		if p.nbuf == p.b {
+			p.flush()
+		}
+
+		if p.nbuf == 0 && len(buf) >= parallelHashMinBlocks*p.b {
+			k := len(buf) / p.b
+			p.writeBlocks(buf[:k*p.b])
+			buf = buf[k*p.b:]
+			continue
+		}
+
+		c := p.b - p.nbuf
+		if c > len(buf) {
+			c = len(buf)
+		}
+		p.inner.Write(buf[:c])
+		p.nbuf += c
+		buf = buf[c:]
+	}
+	return n, nil
+}
+
+// flush absorbs the chaining value of the current block.
+func (p *parallelHash) flush() {
+	p.outer.Write(p.inner.squeeze(p.cvLen))
+	p.inner.Reset()
+	p.nbuf = 0
+	p.blocks++
+}
+
+// writeBlocks hashes whole blocks on all CPUs.
+func (p *parallelHash) writeBlocks(buf []byte) {
+	k := len(buf) / p.b
+	cvs := make([]byte, k*p.cvLen)
+
+	workers := runtime.GOMAXPROCS(0)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < k; i += workers {
+				s := p.inner
+				s.Write(buf[i*p.b : (i+1)*p.b])
+				copy(cvs[i*p.cvLen:], s.squeeze(p.cvLen))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	p.outer.Write(cvs)
+	p.blocks += uint64(k)
+}
+
+func (p *parallelHash) Sum(in []byte) []byte {
+	d := *p
+	if d.nbuf > 0 {
+		d.flush()
+	}
+	d.outer.Write(rightEncode(d.blocks))
+	d.outer.Write(rightEncode(uint64(d.outer.size) * 8))
+	return append(in, d.outer.squeeze(d.outer.size)...)
+}
@@ -0,0 +1,144 @@
+package multihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestCShake(t *testing.T) {
+	// NIST SP 800-185 samples #1 and #3, with an empty function name N
+	// and the customization string S "Email Signature"
+	cases := []struct {
+		code   uint64
+		length int
+		hex    string
+	}{
+		{CSHAKE_128, 32, "c1c36925b6409a04f1b504fcbca9d82b4017277cb5ed2b2065fc1d3814d5aaf5"},
+		{CSHAKE_256, 64, "d008828e2b80ac9d2218ffee1d070c48b8e4c87bff32c9699d5b6896eee0edd164020e2be0560858d9c00c037e34a96937c561a74c412bb4c746469527281c8c"},
+	}
+	for _, tc := range cases {
+		h, err := NewCShakeHasher(tc.code, tc.length, []byte("Email Signature"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte{0, 1, 2, 3})
+		if d := hex.EncodeToString(h.Sum(nil)); d != tc.hex {
+			t.Errorf("%s: got %s, expected %s", Codes[tc.code], d, tc.hex)
+		}
+	}
+
+	// cSHAKE without a function name or a customization is SHAKE
+	h, err := NewCShakeHasher(CSHAKE_128, 32, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte{0, 1, 2, 3})
+
+	ref, _ := Sum([]byte{0, 1, 2, 3}, SHAKE_128, 32)
+	dref, _ := Decode(ref)
+	if !bytes.Equal(h.Sum(nil), dref.Digest) {
+		t.Error("cSHAKE without customization should be SHAKE")
+	}
+
+	if _, err := NewCShakeHasher(SHAKE_128, 32, nil); !errors.Is(err, ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+}
+
+func TestTupleHash(t *testing.T) {
+	// NIST SP 800-185 samples
+	cases := []struct {
+		code   uint64
+		length int
+		custom string
+		tuple  []string
+		hex    string
+	}{
+		{TUPLEHASH_128, 32, "", []string{"000102", "101112131415"}, "c5d8786c1afb9b82111ab34b65b2c0048fa64e6d48e263264ce1707d3ffc8ed1"},
+		{TUPLEHASH_128, 32, "My Tuple App", []string{"000102", "101112131415"}, "75cdb20ff4db1154e841d758e24160c54bae86eb8c13e7f5f40eb35588e96dfb"},
+		{TUPLEHASH_128, 32, "My Tuple App", []string{"000102", "101112131415", "202122232425262728"}, "e60f202c89a2631eda8d4c588ca5fd07f39e5151998deccf973adb3804bb6e84"},
+		{TUPLEHASH_256, 64, "", []string{"000102", "101112131415"}, "cfb7058caca5e668f81a12a20a2195ce97a925f1dba3e7449a56f82201ec607311ac2696b1ab5ea2352df1423bde7bd4bb78c9aed1a853c78672f9eb23bbe194"},
+		{TUPLEHASH_256, 64, "My Tuple App", []string{"000102", "101112131415"}, "147c2191d5ed7efd98dbd96d7ab5a11692576f5fe2a5065f3e33de6bba9f3aa1c4e9a068a289c61c95aab30aee1e410b0b607de3620e24a4e3bf9852a1d4367e"},
+		{TUPLEHASH_256, 64, "My Tuple App", []string{"000102", "101112131415", "202122232425262728"}, "45000be63f9b6bfd89f54717670f69a9bc763591a4f05c50d68891a744bcc6e7d6d5b5e82c018da999ed35b0bb49c9678e526abd8e85c13ed254021db9e790ce"},
+	}
+
+	for i, tc := range cases {
+		var tuple [][]byte
+		for _, x := range tc.tuple {
+			b, _ := hex.DecodeString(x)
+			tuple = append(tuple, b)
+		}
+
+		m, err := SumTuple(tc.code, tc.length, []byte(tc.custom), tuple...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dm, _ := Decode(m)
+		if d := hex.EncodeToString(dm.Digest); d != tc.hex {
+			t.Errorf("%d: got %s", i, d)
+		}
+	}
+
+	// Sum hashes a tuple of one element
+	m, _ := Sum([]byte("foo"), TUPLEHASH_128, -1)
+	m2, _ := SumTuple(TUPLEHASH_128, -1, nil, []byte("foo"))
+	if !bytes.Equal(m, m2) {
+		t.Error("Sum should hash a tuple of one element")
+	}
+	m2, _ = SumTuple(TUPLEHASH_128, -1, nil, []byte("fo"), []byte("o"))
+	if bytes.Equal(m, m2) {
+		t.Error("tuples with the same concatenation should differ")
+	}
+}
+
+func TestParallelHash(t *testing.T) {
+	// NIST SP 800-185 samples #1 and #2
+	x, _ := hex.DecodeString("000102030405060710111213141516172021222324252627")
+	cases := []struct {
+		custom string
+		hex    string
+	}{
+		{"", "ba8dc1d1d979331d3f813603c67f72609ab5e44b94a0b8f9af46514454a2b4f5"},
+		{"Parallel Data", "fc484dcb3f84dceedc353438151bee58157d6efed0445a81f165e495795b7206"},
+	}
+
+	for i, tc := range cases {
+		h, err := NewParallelHasher(PARALLELHASH_128, 32, 8, []byte(tc.custom))
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write(x)
+		if d := hex.EncodeToString(h.Sum(nil)); d != tc.hex {
+			t.Errorf("%d: got %s", i, d)
+		}
+	}
+
+	// large writes are hashed in parallel
+	data := bytes.Repeat([]byte("parallel "), 100000)
+	for _, code := range []uint64{PARALLELHASH_128, PARALLELHASH_256} {
+		ref, err := Sum(data, code, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, _ := NewHasher(code, -1)
+		for p := data; len(p) > 0; {
+			n := 777
+			if n > len(p) {
+				n = len(p)
+			}
+			h.Write(p[:n])
+			p = p[n:]
+		}
+		m, _ := h.Multihash()
+		if !bytes.Equal(m, ref) {
+			t.Errorf("%s: streamed digest differs", Codes[code])
+		}
+	}
+
+	if _, err := NewParallelHasher(PARALLELHASH_128, 32, 0, nil); !errors.Is(err, ErrInvalidParams) {
+		t.Error("expected ErrInvalidParams, got: ", err)
+	}
+}
@@ -0,0 +1,139 @@
+package multihash
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// ErrNullMultihash is returned when scanning NULL into a Multihash.
+// Use a NullMultihash for nullable columns.
+var ErrNullMultihash = errors.New("cannot scan NULL into a multihash")
+
+// Value implements driver.Valuer. Multihashes are stored in their
+// binary form, which suits BYTEA or BLOB columns. Use a MultihashText
+// for TEXT columns. It returns an error if m is not a valid multihash.
+func (m Multihash) Value() (driver.Value, error) {
+	if _, err := Cast(m); err != nil {
+		return nil, err
+	}
+	return []byte(m), nil
+}
+
+// Scan implements sql.Scanner. It accepts multihashes in their binary
+// form, or in any of the text forms of FromString, so that both binary
+// and text columns can be scanned. Bytes are first tried as a binary
+// multihash, then as text, since some drivers return text columns as
+// bytes. The result is always checked with Cast.
+func (m *Multihash) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return ErrNullMultihash
+	case string:
+		return m.scanString(v)
+	case []byte:
+		if _, err := Cast(v); err == nil {
+			// the driver may reuse the buffer
+			*m = append(Multihash{}, v...)
+			return nil
+		}
+		return m.scanString(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into a multihash", src)
+	}
+}
+
+func (m *Multihash) scanString(s string) error {
+	m2, err := FromString(s)
+	if err != nil {
+		return err
+	}
+	*m = m2
+	return nil
+}
+
+// NullMultihash is a Multihash which may be NULL. It implements
+// sql.Scanner and driver.Valuer like Multihash.
+type NullMultihash struct {
+	Multihash Multihash
+	// Valid is true if Multihash is not NULL.
+	Valid bool
+}
+
+// Value implements driver.Valuer.
+func (n NullMultihash) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Multihash.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullMultihash) Scan(src interface{}) error {
+	if src == nil {
+		n.Multihash, n.Valid = nil, false
+		return nil
+	}
+
+	if err := n.Multihash.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer. Unlike Multihash, a MultihashText is
+// stored as a string of its text form with t.Base, which suits TEXT or
+// VARCHAR columns. It returns an error if t.Multihash is not a valid
+// multihash.
+func (t MultihashText) Value() (driver.Value, error) {
+	if _, err := Cast(t.Multihash); err != nil {
+		return nil, err
+	}
+	return t.Multihash.ToString(t.Base)
+}
+
+// Scan implements sql.Scanner like Multihash.Scan, except that bytes are
+// first tried as text, since they come from a text column. It leaves
+// t.Base unchanged.
+func (t *MultihashText) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		if m, err := FromString(string(b)); err == nil {
+			t.Multihash = m
+			return nil
+		}
+	}
+	return t.Multihash.Scan(src)
+}
+
+// NullMultihashText is a MultihashText which may be NULL. It implements
+// sql.Scanner and driver.Valuer like MultihashText.
+type NullMultihashText struct {
+	MultihashText MultihashText
+	// Valid is true if MultihashText is not NULL.
+	Valid bool
+}
+
+// Value implements driver.Valuer.
+func (n NullMultihashText) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.MultihashText.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullMultihashText) Scan(src interface{}) error {
+	if src == nil {
+		n.MultihashText.Multihash, n.Valid = nil, false
+		return nil
+	}
+
+	if err := n.MultihashText.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,253 @@
+package multihash
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeDriver is an in-memory database of a single column. Its only
+// statements are "insert", with one argument, and "select".
+type fakeDriver struct {
+	rows []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.d, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("no transactions") }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int {
+	if s.query == "insert" {
+		return 1
+	}
+	return 0
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.rows = append(s.d.rows, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+type fakeRows struct {
+	rows []driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"hash"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+var fakeDB = &fakeDriver{}
+
+func init() {
+	sql.Register("multihash-fake", fakeDB)
+}
+
+func TestSQL(t *testing.T) {
+	db, err := sql.Open("multihash-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	fakeDB.rows = nil
+
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+	for _, v := range []interface{}{
+		m,
+		NullMultihash{Multihash: m, Valid: true},
+		m.B58String(),
+		"bciqcyjvunnup7rup7gnukpa5gbatie2cfvygja57ud4yuxuimjtoplq",
+		[]byte(m.HexString()),
+		[]byte("f" + m.HexString()),
+	} {
+		if _, err := db.Exec("insert", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := fakeDB.rows[0].([]byte); !ok {
+		t.Errorf("stored a %T", fakeDB.rows[0])
+	}
+
+	rows, err := db.Query("select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var n int
+	for ; rows.Next(); n++ {
+		var m2 Multihash
+		err := rows.Scan(&m2)
+		// bare hex is not a form of FromString
+		if n == 4 {
+			if err == nil {
+				t.Error("expected an error for bare hex")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("row %d: %s", n, err)
+		}
+		if !bytes.Equal(m, m2) {
+			t.Errorf("row %d: scanned %x", n, m2)
+		}
+	}
+	if n != 6 {
+		t.Errorf("scanned %d rows", n)
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	db, err := sql.Open("multihash-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	fakeDB.rows = nil
+
+	if _, err := db.Exec("insert", NullMultihash{}); err != nil {
+		t.Fatal(err)
+	}
+	if fakeDB.rows[0] != nil {
+		t.Errorf("stored %v", fakeDB.rows[0])
+	}
+
+	n := NullMultihash{Multihash: Multihash{1}, Valid: true}
+	if err := db.QueryRow("select").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n.Valid || n.Multihash != nil {
+		t.Errorf("scanned %+v", n)
+	}
+
+	var m Multihash
+	if err := db.QueryRow("select").Scan(&m); !errors.Is(err, ErrNullMultihash) {
+		t.Error("expected ErrNullMultihash, got: ", err)
+	}
+}
+
+func TestSQLText(t *testing.T) {
+	db, err := sql.Open("multihash-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	fakeDB.rows = nil
+
+	m, _ := Sum([]byte("foo"), SHA2_256, -1)
+	for _, v := range []interface{}{
+		MultihashText{Multihash: m},
+		MultihashText{m, Base32},
+		NullMultihashText{MultihashText{m, Base16}, true},
+		NullMultihashText{},
+	} {
+		if _, err := db.Exec("insert", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []driver.Value{
+		"QmRJzsvyCQyizr73Gmms8ZRtvNxmgqumxc2KUp71dfEmoj",
+		"bciqcyjvunnup7rup7gnukpa5gbatie2cfvygja57ud4yuxuimjtoplq",
+		"f12202c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		nil,
+	}
+	for i, v := range fakeDB.rows {
+		if v != want[i] {
+			t.Errorf("row %d: stored %#v, expected %#v", i, v, want[i])
+		}
+	}
+	// drivers may return text columns as bytes
+	fakeDB.rows = append(fakeDB.rows, []byte(want[1].(string)))
+
+	rows, err := db.Query("select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var n int
+	for ; rows.Next(); n++ {
+		nt := NullMultihashText{MultihashText: MultihashText{Base: Base32}}
+		if err := rows.Scan(&nt); err != nil {
+			t.Fatalf("row %d: %s", n, err)
+		}
+		if n == 3 {
+			if nt.Valid || nt.MultihashText.Multihash != nil {
+				t.Errorf("row %d: scanned %+v", n, nt)
+			}
+			continue
+		}
+		if !nt.Valid || !bytes.Equal(m, nt.MultihashText.Multihash) || nt.MultihashText.Base != Base32 {
+			t.Errorf("row %d: scanned %+v", n, nt)
+		}
+	}
+	if n != 5 {
+		t.Errorf("scanned %d rows", n)
+	}
+
+	if _, err := (MultihashText{Multihash{0x12, 0x20, 1}, Base32}).Value(); !errors.Is(err, ErrInconsistentLen{}) {
+		t.Error("expected ErrInconsistentLen, got: ", err)
+	}
+	if _, err := (MultihashText{m, '?'}).Value(); err != ErrUnknownMultibase {
+		t.Error("expected ErrUnknownMultibase, got: ", err)
+	}
+	var mt MultihashText
+	if err := mt.Scan("Qm0"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestSQLErrors(t *testing.T) {
+	var m Multihash
+	for _, src := range []interface{}{"", "Qm0", []byte{0x12, 0x20, 1}, 12, nil} {
+		if err := m.Scan(src); err == nil {
+			t.Errorf("%#v: expected an error", src)
+		}
+	}
+
+	var n NullMultihash
+	if err := n.Scan("Qm0"); err == nil || n.Valid {
+		t.Errorf("expected an error, got %+v", n)
+	}
+
+	if _, err := (Multihash{0x12, 0x20, 1}).Value(); !errors.Is(err, ErrInconsistentLen{}) {
+		t.Error("expected ErrInconsistentLen, got: ", err)
+	}
+	if _, err := (Multihash{}).Value(); !errors.Is(err, ErrTooShort) {
+		t.Error("expected ErrTooShort, got: ", err)
+	}
+
+	// the scanned bytes are copied
+	b, _ := Sum([]byte("foo"), SHA2_256, -1)
+	if err := m.Scan([]byte(b)); err != nil {
+		t.Fatal(err)
+	}
+	b[2]++
+	if bytes.Equal(b, m) {
+		t.Error("Scan did not copy the bytes")
+	}
+}
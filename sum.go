@@ -1,18 +1,15 @@
 package multihash
 
 import (
+	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
 	"errors"
-	"fmt"
-
-	keccak "gx/ipfs/QmQPWTeQJnJE7MYu6dJTiNTQRNuqBr41dis6UgY6Uekmgd/keccakpg"
-	blake2b "gx/ipfs/QmaPHkZLbQQbvcyavn8q1GFHg6o6yeceyHFSJ3Pjf3p3TQ/go-crypto/blake2b"
-	blake2s "gx/ipfs/QmaPHkZLbQQbvcyavn8q1GFHg6o6yeceyHFSJ3Pjf3p3TQ/go-crypto/blake2s"
-	sha3 "gx/ipfs/QmaPHkZLbQQbvcyavn8q1GFHg6o6yeceyHFSJ3Pjf3p3TQ/go-crypto/sha3"
-	"gx/ipfs/QmfJHywXQu98UeZtGJBQrPAR6AtmDjjbe3qjTo9piXHPnx/murmur3"
-	skein "leb.io/hashland/skein" // could use https://github.com/wernerd/Skein3Fish/tree/master/go as well
+	"hash"
+
+	"gx/ipfs/QmaPHkZLbQQbvcyavn8q1GFHg6o6yeceyHFSJ3Pjf3p3TQ/go-crypto/md4"
+	"gx/ipfs/QmaPHkZLbQQbvcyavn8q1GFHg6o6yeceyHFSJ3Pjf3p3TQ/go-crypto/ripemd160"
 )
 
 // ErrSumNotSupported is returned when the Sum function code is not implemented
@@ -22,117 +19,134 @@ var ErrSumNotSupported = errors.New("Function not implemented. Complain to lib m
 // indicates the length of the resulting digest and passing a negative value
 // use default length values for the selected hash function.
 func Sum(data []byte, code uint64, length int) (Multihash, error) {
-	m := Multihash{}
-	err := error(nil)
-	if !ValidCode(code) {
-		return m, fmt.Errorf("invalid multihash code %d", code)
+	h, err := NewHasher(code, length)
+	if err != nil {
+		return nil, err
 	}
 
-	if length < 0 {
-		var ok bool
-		length, ok = DefaultLengths[code]
-		if !ok {
-			return m, fmt.Errorf("no default length for code %d", code)
+	h.Write(data)
+	return h.Multihash()
+}
+
+// builtinHashes holds the hash functions registered at init time.
+// The blake2 and skein ranges are added by builtinHashFunc.
+var builtinHashes = map[uint64]HashFunc{
+	ID:           func(int) (hash.Hash, error) { return &identity{}, nil },
+	SHA1:         fixedHash(sha1.New),
+	SHA2_256:     fixedHash(sha256.New),
+	SHA2_512:     fixedHash(sha512.New),
+	SHA2_224:     fixedHash(sha256.New224),
+	SHA2_384:     fixedHash(sha512.New384),
+	SHA2_512_224: fixedHash(sha512.New512_224),
+	SHA2_512_256: fixedHash(sha512.New512_256),
+	KECCAK_224:   keccakHashFunc(0x01, 28),
+	KECCAK_256:   keccakHashFunc(0x01, 32),
+	KECCAK_384:   keccakHashFunc(0x01, 48),
+	KECCAK_512:   keccakHashFunc(0x01, 64),
+	SHA3_224:     keccakHashFunc(0x06, 28),
+	SHA3_256:     keccakHashFunc(0x06, 32),
+	SHA3_384:     keccakHashFunc(0x06, 48),
+	SHA3_512:     keccakHashFunc(0x06, 64),
+	DBL_SHA2_256: func(int) (hash.Hash, error) { return &doubleSHA256{sha256.New()}, nil },
+	MD4:          fixedHash(md4.New),
+	MD5:          fixedHash(md5.New),
+	RIPEMD_160:   fixedHash(ripemd160.New),
+	HASH160:      func(int) (hash.Hash, error) { return &hash160{sha256.New()}, nil },
+	SHAKE_128:    shakeHashFunc(SHAKE_128, 168),
+	SHAKE_256:    shakeHashFunc(SHAKE_256, 136),
+	BLAKE3:       blake3HashFunc,
+	SM3_256:      func(int) (hash.Hash, error) { return newSM3(), nil },
+	STREEBOG_256: func(int) (hash.Hash, error) { return newStreebog(32), nil },
+	STREEBOG_512: func(int) (hash.Hash, error) { return newStreebog(64), nil },
+	WHIRLPOOL:    func(int) (hash.Hash, error) { return newWhirlpool(), nil },
+
+	KANGAROOTWELVE:   k12HashFunc,
+	CSHAKE_128:       sp800185HashFunc(CSHAKE_128),
+	CSHAKE_256:       sp800185HashFunc(CSHAKE_256),
+	TUPLEHASH_128:    sp800185HashFunc(TUPLEHASH_128),
+	TUPLEHASH_256:    sp800185HashFunc(TUPLEHASH_256),
+	PARALLELHASH_128: sp800185HashFunc(PARALLELHASH_128),
+	PARALLELHASH_256: sp800185HashFunc(PARALLELHASH_256),
+
+	MURMUR3_X64_64:  nonCryptographicHashFunc(MURMUR3_X64_64),
+	MURMUR3_32:      nonCryptographicHashFunc(MURMUR3_32),
+	MURMUR3_X64_128: nonCryptographicHashFunc(MURMUR3_X64_128),
+	XXH_32:          nonCryptographicHashFunc(XXH_32),
+	XXH_64:          nonCryptographicHashFunc(XXH_64),
+	XXH3_64:         nonCryptographicHashFunc(XXH3_64),
+	XXH3_128:        nonCryptographicHashFunc(XXH3_128),
+}
+
+// weakHashes are the built-in legacy cryptographic hash functions which
+// are not considered collision resistant: practical collisions are
+// known for md4, md5 and sha1, while ripemd-160 and hash160 have no
+// known collisions but only offer 80 bits of collision resistance.
+// See also nonCryptographicHashes.
+var weakHashes = map[uint64]bool{
+	SHA1:       true,
+	MD4:        true,
+	MD5:        true,
+	RIPEMD_160: true,
+	HASH160:    true,
+}
+
+// MaxXOFLength is the longest digest, in bytes, that will be squeezed
+// out of an extendable-output function such as SHAKE.
+const MaxXOFLength = 1 << 16
+
+// shakeHashFunc returns a HashFunc for SHAKE with the given rate,
+// producing digests of exactly the requested length.
+func shakeHashFunc(code uint64, rate int) HashFunc {
+	return func(length int) (hash.Hash, error) {
+		if err := checkXOFLength(code, length); err != nil {
+			return nil, err
 		}
+		return newCShake(rate, nil, nil, length), nil
+	}
+}
+
+func checkXOFLength(code uint64, length int) error {
+	if length > MaxXOFLength {
+		return lengthError(code, length, MaxXOFLength)
+	}
+	return nil
+}
+
+// keccakHashFunc returns a HashFunc for Keccak (ds 0x01) or SHA-3
+// (ds 0x06) with the given digest size.
+func keccakHashFunc(ds byte, size int) HashFunc {
+	return func(int) (hash.Hash, error) {
+		return newKeccak(200-2*size, ds, size), nil
+	}
+}
+
+// fixedHash adapts a hash.Hash constructor with a fixed output size.
+func fixedHash(f func() hash.Hash) HashFunc {
+	return func(int) (hash.Hash, error) {
+		return f(), nil
 	}
+}
 
-	var d []byte
+// builtinHashFunc returns the HashFunc for a built-in code.
+func builtinHashFunc(code uint64) HashFunc {
 	switch {
 	case isBlake2s(code):
-		olen := code - BLAKE2S_MIN + 1
-		switch olen {
-		case 32:
-			out := blake2s.Sum256(data)
-			d = out[:]
-		default:
-
-			// var sum [32]byte
-			// var tmp [olen]byte
-			// checkSum(&sum, olen, data)
-			// copy(tmp[:], sum[:olen])
-			// d = tmp
-
-			return nil, fmt.Errorf("unsupported length for blake2s: %d", olen)
-		}
+		olen := int(code - BLAKE2S_MIN + 1)
+		return func(int) (hash.Hash, error) { return newBlake2s(olen, nil, nil, nil), nil }
 	case isBlake2b(code):
-		olen := code - BLAKE2B_MIN + 1
-		switch olen {
-		case 32:
-			out := blake2b.Sum256(data)
-			d = out[:]
-		case 48:
-			out := blake2b.Sum384(data)
-			d = out[:]
-		case 64:
-			out := blake2b.Sum512(data)
-			d = out[:]
-		default:
-
-			// var sum [64]byte
-			// var tmp [olen]byte
-			// checkSum(&sum, olen, data)
-			// copy(tmp[:], sum[:olen])
-			// d = tmp
-
-			return nil, fmt.Errorf("unsupported length for blake2b: %d", olen)
-		}
+		olen := int(code - BLAKE2B_MIN + 1)
+		return func(int) (hash.Hash, error) { return newBlake2b(olen, nil, nil, nil), nil }
 	case isSkein256(code):
-		olen := code - SKEIN256_MIN + 1
-		state, _ := skein.New(256, int(olen))
-		out := state.Sum(data)
-		d = out[:]
+		olen := int(code - SKEIN256_MIN + 1)
+		return func(int) (hash.Hash, error) { return newSkein(256, olen), nil }
 	case isSkein512(code):
-		olen := code - SKEIN512_MIN + 1
-		state, _ := skein.New(512, int(olen))
-		out := state.Sum(data)
-		d = out[:]
+		olen := int(code - SKEIN512_MIN + 1)
+		return func(int) (hash.Hash, error) { return newSkein(512, olen), nil }
 	case isSkein1024(code):
-		olen := code - SKEIN1024_MIN + 1
-		state, _ := skein.New(1024, int(olen))
-		out := state.Sum(data)
-		d = out[:]
-	default:
-		switch code {
-		case ID:
-			d = sumID(data)
-		case SHA1:
-			d = sumSHA1(data)
-		case SHA2_256:
-			d = sumSHA256(data)
-		case SHA2_512:
-			d = sumSHA512(data)
-		case KECCAK_224:
-			d = sumKeccak224(data)
-		case KECCAK_256:
-			d = sumKeccak256(data)
-		case KECCAK_384:
-			d = sumKeccak384(data)
-		case KECCAK_512:
-			d = sumKeccak512(data)
-		case SHA3_224:
-			d = sumSHA3_224(data)
-		case SHA3_256:
-			d = sumSHA3_256(data)
-		case SHA3_384:
-			d = sumSHA3_384(data)
-		case SHA3_512:
-			d = sumSHA3_512(data)
-		case DBL_SHA2_256:
-			d = sumSHA256(sumSHA256(data))
-		case MURMUR3:
-			d, err = sumMURMUR3(data)
-		case SHAKE_128:
-			d = sumSHAKE128(data)
-		case SHAKE_256:
-			d = sumSHAKE256(data)
-		default:
-			return m, ErrSumNotSupported
-		}
+		olen := int(code - SKEIN1024_MIN + 1)
+		return func(int) (hash.Hash, error) { return newSkein(1024, olen), nil }
 	}
-	if err != nil {
-		return m, err
-	}
-	return Encode(d[0:length], code)
+	return builtinHashes[code]
 }
 
 func isBlake2s(code uint64) bool {
@@ -154,95 +168,41 @@ func isSkein1024(code uint64) bool {
 	return code >= SKEIN1024_MIN && code <= SKEIN1024_MAX
 }
 
-func sumID(data []byte) []byte {
-	return data
-}
-
-func sumSHA1(data []byte) []byte {
-	a := sha1.Sum(data)
-	return a[0:20]
-}
-
-func sumSHA256(data []byte) []byte {
-	a := sha256.Sum256(data)
-	return a[0:32]
-}
-
-func sumSHA512(data []byte) []byte {
-	a := sha512.Sum512(data)
-	return a[0:64]
-}
-
-func sumKeccak224(data []byte) []byte {
-	h := keccak.New224()
-	h.Write(data)
-	return h.Sum(nil)
-}
-
-func sumKeccak256(data []byte) []byte {
-	h := keccak.New256()
-	h.Write(data)
-	return h.Sum(nil)
-}
-
-func sumKeccak384(data []byte) []byte {
-	h := keccak.New384()
-	h.Write(data)
-	return h.Sum(nil)
+// identity is the "id" hash function: its digest is its input.
+// It necessarily buffers everything written to it.
+type identity struct {
+	buf []byte
 }
 
-func sumKeccak512(data []byte) []byte {
-	h := keccak.New512()
-	h.Write(data)
-	return h.Sum(nil)
+func (i *identity) Write(p []byte) (int, error) {
+	i.buf = append(i.buf, p...)
+	return len(p), nil
 }
 
-func sumSHA3(data []byte) ([]byte, error) {
-	h := sha3.New512()
-	if _, err := h.Write(data); err != nil {
-		return nil, err
-	}
-	return h.Sum(nil), nil
-}
+func (i *identity) Sum(b []byte) []byte { return append(b, i.buf...) }
+func (i *identity) Reset()              { i.buf = nil }
+func (i *identity) Size() int           { return len(i.buf) }
+func (i *identity) BlockSize() int      { return 1 }
 
-func sumSHA3_512(data []byte) []byte {
-	a := sha3.Sum512(data)
-	return a[:]
+// doubleSHA256 computes sha256(sha256(data)).
+type doubleSHA256 struct {
+	hash.Hash
 }
 
-func sumMURMUR3(data []byte) ([]byte, error) {
-	number := murmur3.Sum32(data)
-	bytes := make([]byte, 4)
-	for i := range bytes {
-		bytes[i] = byte(number & 0xff)
-		number >>= 8
-	}
-	return bytes, nil
+func (d *doubleSHA256) Sum(b []byte) []byte {
+	h := sha256.Sum256(d.Hash.Sum(nil))
+	return append(b, h[:]...)
 }
 
-func sumSHAKE128(data []byte) []byte {
-	bytes := make([]byte, 32)
-	sha3.ShakeSum128(bytes, data)
-	return bytes
+// hash160 computes ripemd160(sha256(data)).
+type hash160 struct {
+	hash.Hash
 }
 
-func sumSHAKE256(data []byte) []byte {
-	bytes := make([]byte, 64)
-	sha3.ShakeSum256(bytes, data)
-	return bytes
+func (d *hash160) Sum(b []byte) []byte {
+	r := ripemd160.New()
+	r.Write(d.Hash.Sum(nil))
+	return r.Sum(b)
 }
 
-func sumSHA3_384(data []byte) []byte {
-	a := sha3.Sum384(data)
-	return a[:]
-}
-
-func sumSHA3_256(data []byte) []byte {
-	a := sha3.Sum256(data)
-	return a[:]
-}
-
-func sumSHA3_224(data []byte) []byte {
-	a := sha3.Sum224(data)
-	return a[:]
-}
+func (d *hash160) Size() int { return ripemd160.Size }
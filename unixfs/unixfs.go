@@ -0,0 +1,269 @@
+// Package unixfs computes the content identifiers (CIDs) IPFS gives to
+// files, as "ipfs add --only-hash" does, without an IPFS node.
+//
+// Files are split into chunks of 256 KiB, which become the leaves of a
+// balanced DAG of dag-pb nodes holding UnixFS metadata, with at most
+// 174 links per node. The CID of the file is the sha2-256 multihash of
+// the root node.
+package unixfs
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+
+	mh "github.com/multiformats/go-multihash"
+)
+
+// Layout parameters of "ipfs add".
+const (
+	DefaultChunkSize = 256 << 10
+	LinksPerNode     = 174
+)
+
+// Multicodec codes of the blocks.
+const (
+	Raw   = 0x55
+	DagPB = 0x70
+)
+
+const (
+	// hashCode is the hash function of the blocks
+	hashCode = mh.SHA2_256
+	// typeFile is the UnixFS type of file nodes
+	typeFile = 2
+)
+
+// ErrInvalidOptions is returned for options "ipfs add" does not
+// support.
+var ErrInvalidOptions = errors.New("unixfs: invalid options")
+
+// Options are the options of "ipfs add" which change the CID of a
+// file. The zero value gives the CIDv0 of the default settings.
+type Options struct {
+	// CIDVersion is 0 or 1. Note that "ipfs add --cid-version 1"
+	// also implies --raw-leaves.
+	CIDVersion int
+
+	// RawLeaves stores the chunks as raw blocks instead of wrapping
+	// them in UnixFS nodes. Raw blocks always have a CIDv1.
+	RawLeaves bool
+
+	// ChunkSize is the size of the chunks, as in "--chunker
+	// size-N". Zero means DefaultChunkSize.
+	ChunkSize int
+}
+
+// CID is a content identifier.
+type CID struct {
+	Version int
+	Codec   uint64
+	Hash    mh.Multihash
+}
+
+// Bytes returns the binary form of c: the bare multihash for a CIDv0.
+func (c CID) Bytes() []byte {
+	if c.Version == 0 {
+		return []byte(c.Hash)
+	}
+
+	buf := make([]byte, 2*binary.MaxVarintLen64, 2*binary.MaxVarintLen64+len(c.Hash))
+	n := binary.PutUvarint(buf, uint64(c.Version))
+	n += binary.PutUvarint(buf[n:], c.Codec)
+	return append(buf[:n], c.Hash...)
+}
+
+// String returns c as IPFS prints it: in base58 for a CIDv0, and in
+// lowercase base32 with its multibase prefix "b" for a CIDv1.
+func (c CID) String() string {
+	if c.Version == 0 {
+		return c.Hash.B58String()
+	}
+
+	enc := base32.StdEncoding.WithPadding(base32.NoPadding)
+	return "b" + strings.ToLower(enc.EncodeToString(c.Bytes()))
+}
+
+// node is a block of the DAG, as its parent links to it.
+type node struct {
+	cid      CID
+	fileSize uint64
+	// tsize is the size of the block and of all its descendants
+	tsize uint64
+}
+
+// Sum returns the CID of a file with the given data.
+func Sum(data []byte, opts Options) (CID, error) {
+	return SumReader(bytes.NewReader(data), opts)
+}
+
+// SumReader returns the CID of a file with the data read from r. It
+// reads r by chunks, and only keeps the CIDs of the pending links in
+// memory.
+func SumReader(r io.Reader, opts Options) (CID, error) {
+	if opts.CIDVersion != 0 && opts.CIDVersion != 1 {
+		return CID{}, ErrInvalidOptions
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = DefaultChunkSize
+	}
+	if opts.ChunkSize < 0 {
+		return CID{}, ErrInvalidOptions
+	}
+
+	// levels[i] are the nodes at height i waiting for their parent.
+	// A level is only turned into a parent when it overflows, or
+	// at the end: until then, it could be the root.
+	var levels [][]node
+	var add func(height int, n node) error
+	add = func(height int, n node) error {
+		if height == len(levels) {
+			levels = append(levels, nil)
+		}
+		if len(levels[height]) == LinksPerNode {
+			parent, err := newParent(levels[height], opts)
+			if err != nil {
+				return err
+			}
+			levels[height] = levels[height][:0]
+			if err := add(height+1, parent); err != nil {
+				return err
+			}
+		}
+		levels[height] = append(levels[height], n)
+		return nil
+	}
+
+	buf := make([]byte, opts.ChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if err == io.EOF && len(levels) > 0 {
+			break
+		}
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return CID{}, err
+		}
+
+		// an empty file has a single empty leaf
+		leaf, lerr := newLeaf(buf[:n], opts)
+		if lerr != nil {
+			return CID{}, lerr
+		}
+		if lerr = add(0, leaf); lerr != nil {
+			return CID{}, lerr
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	for height := 0; ; height++ {
+		pending := levels[height]
+		if height == len(levels)-1 && len(pending) == 1 {
+			return pending[0].cid, nil
+		}
+
+		parent, err := newParent(pending, opts)
+		if err != nil {
+			return CID{}, err
+		}
+		if height == len(levels)-1 {
+			return parent.cid, nil
+		}
+		if err := add(height+1, parent); err != nil {
+			return CID{}, err
+		}
+	}
+}
+
+func newLeaf(data []byte, opts Options) (node, error) {
+	if opts.RawLeaves {
+		m, err := mh.Sum(data, hashCode, -1)
+		if err != nil {
+			return node{}, err
+		}
+		return node{
+			cid:      CID{Version: 1, Codec: Raw, Hash: m},
+			fileSize: uint64(len(data)),
+			tsize:    uint64(len(data)),
+		}, nil
+	}
+
+	var fsData []byte
+	fsData = appendVarintField(fsData, 1, typeFile)
+	if len(data) > 0 {
+		fsData = appendBytesField(fsData, 2, data)
+	}
+	fsData = appendVarintField(fsData, 3, uint64(len(data)))
+
+	return newDagPB(nil, fsData, uint64(len(data)), opts)
+}
+
+func newParent(children []node, opts Options) (node, error) {
+	var fileSize uint64
+	for _, c := range children {
+		fileSize += c.fileSize
+	}
+
+	var fsData []byte
+	fsData = appendVarintField(fsData, 1, typeFile)
+	fsData = appendVarintField(fsData, 3, fileSize)
+	for _, c := range children {
+		fsData = appendVarintField(fsData, 4, c.fileSize)
+	}
+
+	return newDagPB(children, fsData, fileSize, opts)
+}
+
+// newDagPB encodes a dag-pb node: its links come first, and their
+// name is always present, even if empty.
+func newDagPB(links []node, fsData []byte, fileSize uint64, opts Options) (node, error) {
+	var block []byte
+	tsize := uint64(0)
+	for _, l := range links {
+		var link []byte
+		link = appendBytesField(link, 1, l.cid.Bytes())
+		link = appendBytesField(link, 2, nil)
+		link = appendVarintField(link, 3, l.tsize)
+
+		block = appendBytesField(block, 2, link)
+		tsize += l.tsize
+	}
+	block = appendBytesField(block, 1, fsData)
+
+	m, err := mh.Sum(block, hashCode, -1)
+	if err != nil {
+		return node{}, err
+	}
+	return node{
+		cid:      CID{Version: opts.CIDVersion, Codec: DagPB, Hash: m},
+		fileSize: fileSize,
+		tsize:    tsize + uint64(len(block)),
+	}, nil
+}
+
+// protobuf wire types
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	b = appendVarint(b, uint64(field<<3|wireVarint))
+	return appendVarint(b, v)
+}
+
+func appendBytesField(b []byte, field int, data []byte) []byte {
+	b = appendVarint(b, uint64(field<<3|wireBytes))
+	b = appendVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
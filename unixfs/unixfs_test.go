@@ -0,0 +1,131 @@
+package unixfs
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+// seededData returns the data of NewSeededRand of go-ipfs-util, which
+// the importer tests of IPFS use.
+func seededData(n int, seed int64) []byte {
+	r := rand.New(rand.NewSource(seed))
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(r.Intn(255))
+	}
+	return data
+}
+
+func TestSum(t *testing.T) {
+	big := seededData(10<<20, 0xdeadbeef)
+
+	cases := []struct {
+		data []byte
+		opts Options
+		cid  string
+	}{
+		{nil, Options{}, "QmbFMke1KXqnYyBBWxB74N4c5SBnJMVAiMNRcGu6x1AwQH"},
+		{[]byte("hello world\n"), Options{}, "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"},
+		{big, Options{}, "QmZN1qquw84zhV4j6vT56tCcmFxaDaySL1ezTXFvMdNmrK"},
+		{big, Options{CIDVersion: 1, RawLeaves: true}, "bafybeieyxejezqto5xwcxtvh5tskowwxrn3hmbk3hcgredji3g7abtnfkq"},
+	}
+
+	for _, tc := range cases {
+		c, err := Sum(tc.data, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.String() != tc.cid {
+			t.Errorf("%d bytes, %+v: got %s, expected %s", len(tc.data), tc.opts, c, tc.cid)
+		}
+	}
+}
+
+// balancedRoot builds the DAG of data as the balanced layout of
+// go-unixfs does, by filling a tree one level deeper at a time.
+func balancedRoot(t *testing.T, data []byte, opts Options) CID {
+	var leaves []node
+	for i := 0; i == 0 || i < len(data); i += opts.ChunkSize {
+		end := i + opts.ChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		leaf, err := newLeaf(data[i:end], opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaves = append(leaves, leaf)
+	}
+
+	var fill func(depth int) node
+	fill = func(depth int) node {
+		var children []node
+		for len(children) < LinksPerNode && len(leaves) > 0 {
+			if depth == 1 {
+				children = append(children, leaves[0])
+				leaves = leaves[1:]
+			} else {
+				children = append(children, fill(depth-1))
+			}
+		}
+		n, err := newParent(children, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	root := leaves[0]
+	leaves = leaves[1:]
+	for depth := 1; len(leaves) > 0; depth++ {
+		children := []node{root}
+		for len(children) < LinksPerNode && len(leaves) > 0 {
+			if depth == 1 {
+				children = append(children, leaves[0])
+				leaves = leaves[1:]
+			} else {
+				children = append(children, fill(depth-1))
+			}
+		}
+		var err error
+		if root, err = newParent(children, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root.cid
+}
+
+func TestSumLayout(t *testing.T) {
+	data := seededData(LinksPerNode*LinksPerNode*4+40, 1)
+
+	for _, n := range []int{0, 1, 4, 5, 4 * LinksPerNode, 4*LinksPerNode + 1, 4 * LinksPerNode * LinksPerNode, len(data)} {
+		for _, opts := range []Options{
+			{ChunkSize: 4},
+			{ChunkSize: 4, CIDVersion: 1, RawLeaves: true},
+		} {
+			c, err := SumReader(iotest.OneByteReader(bytes.NewReader(data[:n])), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := balancedRoot(t, data[:n], opts); c.String() != want.String() {
+				t.Errorf("%d bytes, %+v: got %s, expected %s", n, opts, c, want)
+			}
+		}
+	}
+}
+
+func TestSumErrors(t *testing.T) {
+	for _, opts := range []Options{{CIDVersion: 2}, {ChunkSize: -1}} {
+		if _, err := Sum(nil, opts); err != ErrInvalidOptions {
+			t.Errorf("%+v: expected ErrInvalidOptions, got: %v", opts, err)
+		}
+	}
+
+	_, err := SumReader(iotest.TimeoutReader(iotest.OneByteReader(bytes.NewReader(make([]byte, 10)))), Options{})
+	if !errors.Is(err, iotest.ErrTimeout) {
+		t.Error("expected iotest.ErrTimeout, got: ", err)
+	}
+}
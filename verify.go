@@ -0,0 +1,88 @@
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDigestMismatch is the cause of a *MismatchError. Test for it
+// with errors.Is(err, ErrDigestMismatch).
+var ErrDigestMismatch = errors.New("multihash digest mismatch")
+
+// MismatchError is returned by a VerifyingReader when the data does
+// not match the expected multihash.
+type MismatchError struct {
+	Expected Multihash
+	Actual   Multihash
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", ErrDigestMismatch, e.Expected.HexString(), e.Actual.HexString())
+}
+
+func (e *MismatchError) Unwrap() error {
+	return ErrDigestMismatch
+}
+
+// VerifyingReader is an io.Reader which hashes the data read through
+// it, and checks it against a multihash. Once the underlying reader
+// is exhausted, Read returns io.EOF if the data matches, and a
+// *MismatchError otherwise. The data must therefore only be trusted
+// once Read returned io.EOF.
+type VerifyingReader struct {
+	r        io.Reader
+	h        Hasher
+	code     uint64
+	expected Multihash
+	err      error
+}
+
+// NewVerifyingReader returns a VerifyingReader checking the data of r
+// against expected, which selects the hash function and the length
+// of the digest.
+func NewVerifyingReader(r io.Reader, expected Multihash) (*VerifyingReader, error) {
+	dm, err := Decode(expected)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := NewHasher(dm.Code, dm.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyingReader{r: r, h: h, code: dm.Code, expected: expected}, nil
+}
+
+func (v *VerifyingReader) Read(buf []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+
+	n, err := v.r.Read(buf)
+	v.h.Write(buf[:n])
+
+	if err == io.EOF {
+		err = v.verify()
+		v.err = err
+	}
+	return n, err
+}
+
+func (v *VerifyingReader) verify() error {
+	m, err := v.h.Multihash()
+	if err != nil {
+		// the data of an identity multihash was not of its length:
+		// report the identity multihash of all the data
+		if m, err = Encode(v.h.Sum(nil), v.code); err != nil {
+			return err
+		}
+	}
+
+	if !bytes.Equal(m, v.expected) {
+		return &MismatchError{Expected: v.expected, Actual: m}
+	}
+	return io.EOF
+}
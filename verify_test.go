@@ -0,0 +1,97 @@
+package multihash
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestVerifyingReader(t *testing.T) {
+	data := bytes.Repeat([]byte("verify me "), 1000)
+
+	for _, code := range []uint64{ID, SHA1, SHA2_256, SHA3_256, BLAKE2B_MAX, SHAKE_128} {
+		for _, length := range []int{-1, 12} {
+			if code == ID {
+				// identity digests are the data itself
+				length = len(data)
+			}
+			m, err := Sum(data, code, length)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := NewVerifyingReader(iotest.OneByteReader(bytes.NewReader(data)), m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Errorf("%s: %s", Codes[code], err)
+			}
+			if !bytes.Equal(out, data) {
+				t.Errorf("%s: data altered", Codes[code])
+			}
+
+			bad := append([]byte{'!'}, data[1:]...)
+			r, err = NewVerifyingReader(bytes.NewReader(bad), m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ioutil.ReadAll(r)
+			if !errors.Is(err, ErrDigestMismatch) {
+				t.Errorf("%s: expected ErrDigestMismatch, got: %v", Codes[code], err)
+			}
+
+			var e *MismatchError
+			if !errors.As(err, &e) || !bytes.Equal(e.Expected, m) {
+				t.Errorf("%s: wrong mismatch error: %v", Codes[code], err)
+			}
+
+			// the error sticks
+			if _, err := r.Read(make([]byte, 1)); !errors.Is(err, ErrDigestMismatch) {
+				t.Errorf("%s: expected the mismatch again, got: %v", Codes[code], err)
+			}
+		}
+	}
+}
+
+func TestVerifyingReaderErrors(t *testing.T) {
+	if _, err := NewVerifyingReader(bytes.NewReader(nil), Multihash{0x12, 0x20}); err == nil {
+		t.Error("expected an error for an invalid multihash")
+	}
+
+	m, _ := Encode(make([]byte, 32), HMAC_SHA2_256)
+	if _, err := NewVerifyingReader(bytes.NewReader(nil), m); !errors.Is(err, ErrKeyRequired) {
+		t.Error("expected ErrKeyRequired, got: ", err)
+	}
+
+	// identity data shorter and longer than the expected length
+	idCases := []struct {
+		expected, data string
+	}{
+		{"foobar", "foo"},
+		{"foo", "fooEVIL"},
+	}
+	for _, tc := range idCases {
+		m, _ = Sum([]byte(tc.expected), ID, len(tc.expected))
+		r, _ := NewVerifyingReader(bytes.NewReader([]byte(tc.data)), m)
+		_, err := io.Copy(ioutil.Discard, r)
+		var e *MismatchError
+		if !errors.As(err, &e) || !errors.Is(err, ErrDigestMismatch) {
+			t.Errorf("%s: expected a *MismatchError, got: %v", tc.data, err)
+			continue
+		}
+		if want, _ := Sum([]byte(tc.data), ID, len(tc.data)); !bytes.Equal(e.Actual, want) || !bytes.Equal(e.Expected, m) {
+			t.Errorf("%s: got %+v", tc.data, e)
+		}
+	}
+
+	m, _ = Sum([]byte("foo"), SHA1, -1)
+	r, _ := NewVerifyingReader(iotest.TimeoutReader(bytes.NewReader([]byte("foo"))), m)
+	if _, err := io.Copy(ioutil.Discard, r); err != iotest.ErrTimeout {
+		t.Error("expected read errors to pass through, got: ", err)
+	}
+}
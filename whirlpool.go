@@ -0,0 +1,157 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// This is Whirlpool, in its final version of ISO/IEC 10118-3:2004.
+
+// whirlpoolT[x] is the row contribution of the substituted byte x in
+// the first column; other columns are byte rotations of it.
+var whirlpoolT [256]uint64
+
+// whirlpoolRC are the round constants.
+var whirlpoolRC [10]uint64
+
+func init() {
+	// the substitution box is built from the mini boxes E and R
+	e := [16]byte{0x1, 0xb, 0x9, 0xc, 0xd, 0x6, 0xf, 0x3, 0xe, 0x8, 0x7, 0x4, 0xa, 0x2, 0x5, 0x0}
+	r := [16]byte{0x7, 0xc, 0xb, 0xd, 0xe, 0x4, 0x9, 0xf, 0x6, 0x3, 0x8, 0xa, 0x2, 0x5, 0x1, 0x0}
+	var einv [16]byte
+	for i, x := range e {
+		einv[x] = byte(i)
+	}
+
+	var sbox [256]byte
+	for u := range sbox {
+		a, b := e[u>>4], einv[u&0xf]
+		t := r[a^b]
+		sbox[u] = e[a^t]<<4 | einv[b^t]
+	}
+
+	// the first row of the circulant MDS matrix
+	c := [8]byte{1, 1, 4, 1, 8, 5, 2, 9}
+	for x := range whirlpoolT {
+		var v uint64
+		for j := range c {
+			v = v<<8 | uint64(whirlpoolMul(sbox[x], c[j]))
+		}
+		whirlpoolT[x] = v
+	}
+
+	for i := range whirlpoolRC {
+		whirlpoolRC[i] = binary.BigEndian.Uint64(sbox[8*i:])
+	}
+}
+
+// whirlpoolMul multiplies in GF(2^8) modulo x^8+x^4+x^3+x^2+1.
+func whirlpoolMul(a, b byte) byte {
+	var p byte
+	for ; b > 0; b >>= 1 {
+		if b&1 == 1 {
+			p ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1d
+		}
+	}
+	return p
+}
+
+type whirlpool struct {
+	h    [8]uint64
+	buf  [64]byte
+	nbuf int
+	n    uint64
+}
+
+func newWhirlpool() *whirlpool {
+	return &whirlpool{}
+}
+
+func (w *whirlpool) Reset() {
+	*w = whirlpool{}
+}
+
+func (w *whirlpool) Size() int      { return 64 }
+func (w *whirlpool) BlockSize() int { return 64 }
+
+func (w *whirlpool) Write(p []byte) (int, error) {
+	n := len(p)
+	w.n += uint64(n)
+
+	if w.nbuf > 0 {
+		c := copy(w.buf[w.nbuf:], p)
+		w.nbuf += c
+		p = p[c:]
+		if w.nbuf < 64 {
+			return n, nil
+		}
+		w.block(w.buf[:])
+		w.nbuf = 0
+	}
+
+	for ; len(p) >= 64; p = p[64:] {
+		w.block(p)
+	}
+	w.nbuf = copy(w.buf[:], p)
+	return n, nil
+}
+
+// whirlpoolRound applies the substitution, the cyclic permutation and
+// the linear diffusion to the rows of a.
+func whirlpoolRound(a *[8]uint64) [8]uint64 {
+	var b [8]uint64
+	for i := range b {
+		var v uint64
+		for k := 0; k < 8; k++ {
+			x := byte(a[(i-k)&7] >> uint(56-8*k))
+			v ^= bits.RotateLeft64(whirlpoolT[x], -8*k)
+		}
+		b[i] = v
+	}
+	return b
+}
+
+func (w *whirlpool) block(p []byte) {
+	var m, k, s [8]uint64
+	for i := range m {
+		m[i] = binary.BigEndian.Uint64(p[8*i:])
+		k[i] = w.h[i]
+		s[i] = m[i] ^ k[i]
+	}
+
+	for r := range whirlpoolRC {
+		k = whirlpoolRound(&k)
+		k[0] ^= whirlpoolRC[r]
+		s = whirlpoolRound(&s)
+		for i := range s {
+			s[i] ^= k[i]
+		}
+	}
+
+	for i := range w.h {
+		w.h[i] ^= s[i] ^ m[i]
+	}
+}
+
+func (w *whirlpool) Sum(b []byte) []byte {
+	d := *w
+
+	// the length is a 256-bit number
+	var pad [96]byte
+	pad[0] = 0x80
+	padLen := 1 + (31-int(d.n%64)+64)%64
+	binary.BigEndian.PutUint64(pad[padLen+16:], d.n>>61)
+	binary.BigEndian.PutUint64(pad[padLen+24:], d.n<<3)
+	d.Write(pad[:padLen+32])
+
+	var out [64]byte
+	for i, v := range d.h {
+		binary.BigEndian.PutUint64(out[8*i:], v)
+	}
+	return append(b, out[:]...)
+}
@@ -0,0 +1,18 @@
+package multihash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWhirlpool(t *testing.T) {
+	testDigests(t, WHIRLPOOL, []digestTestCase{
+		{[]byte(""), "19fa61d75522a4669b44e39c1d2e1726c530232130d407f89afee0964997f7a73e83be698b288febcf88e3e03c4f0757ea8964e59b63d93708b138cc42a66eb3"},
+		{[]byte("abc"), "4e2448a4c6f486bb16b6562c73b4020bf3043e3a731bce721ae1b303d97e6d4c7181eebdb6c57e277d0e34957114cbd6c797fc9d95d8b582d225292076d4eef5"},
+		{bytes.Repeat([]byte("abcd"), 16), "dce301f97bac38c381dfa0db98cd9318f740f94a045aa4af6c169561b387dd3a8fe586b9bd435d45979a3cf7399dcfb5692040e95678beadec62e8be7d7f3b9a"},
+		{patternInput(31), "58bee92be003ccc34f9ce8c0b323c6baf1297460baab4998cb3b52d2bbaa24d1b06cb597eb2e609a008572ff93710e3a7f42ac53e3ff09d4733757eaca41e20c"},
+		{patternInput(32), "888aeb1be2becb28598556a128afea037d0689c8d13d9894f1416b2c48b2551cb2fda321a26cc4d7e1c87332d7a3c18ffb455c92c0e7aaf829fa40b8a28bb656"},
+		{patternInput(33), "19099b4e8abf225dc7bd1c1dc6d52f54e8fb7e4eae0ab19293c686e6fd2828221a1153bba4c143795d1a718585d9255b6dc911c0eda5e0042a10565aa5d6d8e7"},
+		{patternInput(1000), "9780c3fa4c818db4ad07280667e910203f2c01fcb3980a059ab227d0468f8da0ebe26629da70082750698549ef20ac643d8309058597184b5a9516c1a682f14e"},
+	})
+}
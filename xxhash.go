@@ -0,0 +1,573 @@
+package multihash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// This is the xxHash family: XXH32, XXH64 and XXH3, which are not
+// cryptographic hash functions. Digests are in the canonical form of
+// the reference implementation, which is big endian; the high half
+// of the 128-bit XXH3 hash comes first.
+
+const (
+	xxhPrime32_1 = 2654435761
+	xxhPrime32_2 = 2246822519
+	xxhPrime32_3 = 3266489917
+	xxhPrime32_4 = 668265263
+	xxhPrime32_5 = 374761393
+
+	xxhPrime64_1 = 11400714785074694791
+	xxhPrime64_2 = 14029467366897019727
+	xxhPrime64_3 = 1609587929392839161
+	xxhPrime64_4 = 9650029242287828579
+	xxhPrime64_5 = 2870177450012600261
+)
+
+// xxh32 is XXH32.
+type xxh32 struct {
+	seed uint32
+	v    [4]uint32
+	buf  [16]byte
+	nbuf int
+	n    uint64
+}
+
+func newXXH32(seed uint32) *xxh32 {
+	x := &xxh32{seed: seed}
+	x.Reset()
+	return x
+}
+
+func (x *xxh32) Reset() {
+	*x = xxh32{seed: x.seed}
+	x.v = [4]uint32{x.seed + xxhPrime32_1 + xxhPrime32_2, x.seed + xxhPrime32_2, x.seed, x.seed - xxhPrime32_1}
+}
+
+func (x *xxh32) Size() int      { return 4 }
+func (x *xxh32) BlockSize() int { return 16 }
+
+func xxh32Round(acc, in uint32) uint32 {
+	return bits.RotateLeft32(acc+in*xxhPrime32_2, 13) * xxhPrime32_1
+}
+
+func (x *xxh32) Write(p []byte) (int, error) {
+	n := len(p)
+	x.n += uint64(n)
+
+	if x.nbuf > 0 {
+		c := copy(x.buf[x.nbuf:], p)
+		x.nbuf += c
+		p = p[c:]
+		if x.nbuf < 16 {
+			return n, nil
+		}
+		x.stripe(x.buf[:])
+		x.nbuf = 0
+	}
+
+	for ; len(p) >= 16; p = p[16:] {
+		x.stripe(p)
+	}
+	x.nbuf = copy(x.buf[:], p)
+	return n, nil
+}
+
+func (x *xxh32) stripe(p []byte) {
+	for i := range x.v {
+		x.v[i] = xxh32Round(x.v[i], binary.LittleEndian.Uint32(p[4*i:]))
+	}
+}
+
+func (x *xxh32) Sum32() uint32 {
+	var h uint32
+	if x.n >= 16 {
+		h = bits.RotateLeft32(x.v[0], 1) + bits.RotateLeft32(x.v[1], 7) +
+			bits.RotateLeft32(x.v[2], 12) + bits.RotateLeft32(x.v[3], 18)
+	} else {
+		h = x.seed + xxhPrime32_5
+	}
+	h += uint32(x.n)
+
+	p := x.buf[:x.nbuf]
+	for ; len(p) >= 4; p = p[4:] {
+		h += binary.LittleEndian.Uint32(p) * xxhPrime32_3
+		h = bits.RotateLeft32(h, 17) * xxhPrime32_4
+	}
+	for _, b := range p {
+		h += uint32(b) * xxhPrime32_5
+		h = bits.RotateLeft32(h, 11) * xxhPrime32_1
+	}
+
+	h ^= h >> 15
+	h *= xxhPrime32_2
+	h ^= h >> 13
+	h *= xxhPrime32_3
+	h ^= h >> 16
+	return h
+}
+
+func (x *xxh32) Sum(b []byte) []byte {
+	var d [4]byte
+	binary.BigEndian.PutUint32(d[:], x.Sum32())
+	return append(b, d[:]...)
+}
+
+// xxh64 is XXH64.
+type xxh64 struct {
+	seed uint64
+	v    [4]uint64
+	buf  [32]byte
+	nbuf int
+	n    uint64
+}
+
+func newXXH64(seed uint64) *xxh64 {
+	x := &xxh64{seed: seed}
+	x.Reset()
+	return x
+}
+
+func (x *xxh64) Reset() {
+	*x = xxh64{seed: x.seed}
+	x.v = [4]uint64{x.seed + xxhPrime64_1 + xxhPrime64_2, x.seed + xxhPrime64_2, x.seed, x.seed - xxhPrime64_1}
+}
+
+func (x *xxh64) Size() int      { return 8 }
+func (x *xxh64) BlockSize() int { return 32 }
+
+func xxh64Round(acc, in uint64) uint64 {
+	return bits.RotateLeft64(acc+in*xxhPrime64_2, 31) * xxhPrime64_1
+}
+
+func xxh64MergeRound(h, v uint64) uint64 {
+	h ^= xxh64Round(0, v)
+	return h*xxhPrime64_1 + xxhPrime64_4
+}
+
+func (x *xxh64) Write(p []byte) (int, error) {
+	n := len(p)
+	x.n += uint64(n)
+
+	if x.nbuf > 0 {
+		c := copy(x.buf[x.nbuf:], p)
+		x.nbuf += c
+		p = p[c:]
+		if x.nbuf < 32 {
+			return n, nil
+		}
+		x.stripe(x.buf[:])
+		x.nbuf = 0
+	}
+
+	for ; len(p) >= 32; p = p[32:] {
+		x.stripe(p)
+	}
+	x.nbuf = copy(x.buf[:], p)
+	return n, nil
+}
+
+func (x *xxh64) stripe(p []byte) {
+	for i := range x.v {
+		x.v[i] = xxh64Round(x.v[i], binary.LittleEndian.Uint64(p[8*i:]))
+	}
+}
+
+func (x *xxh64) Sum64() uint64 {
+	var h uint64
+	if x.n >= 32 {
+		h = bits.RotateLeft64(x.v[0], 1) + bits.RotateLeft64(x.v[1], 7) +
+			bits.RotateLeft64(x.v[2], 12) + bits.RotateLeft64(x.v[3], 18)
+		for _, v := range x.v {
+			h = xxh64MergeRound(h, v)
+		}
+	} else {
+		h = x.seed + xxhPrime64_5
+	}
+	h += x.n
+
+	p := x.buf[:x.nbuf]
+	for ; len(p) >= 8; p = p[8:] {
+		h ^= xxh64Round(0, binary.LittleEndian.Uint64(p))
+		h = bits.RotateLeft64(h, 27)*xxhPrime64_1 + xxhPrime64_4
+	}
+	if len(p) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(p)) * xxhPrime64_1
+		h = bits.RotateLeft64(h, 23)*xxhPrime64_2 + xxhPrime64_3
+		p = p[4:]
+	}
+	for _, b := range p {
+		h ^= uint64(b) * xxhPrime64_5
+		h = bits.RotateLeft64(h, 11) * xxhPrime64_1
+	}
+	return xxh64Avalanche(h)
+}
+
+func xxh64Avalanche(h uint64) uint64 {
+	h ^= h >> 33
+	h *= xxhPrime64_2
+	h ^= h >> 29
+	h *= xxhPrime64_3
+	h ^= h >> 32
+	return h
+}
+
+func (x *xxh64) Sum(b []byte) []byte {
+	var d [8]byte
+	binary.BigEndian.PutUint64(d[:], x.Sum64())
+	return append(b, d[:]...)
+}
+
+// xxh3Secret is the default secret of XXH3.
+var xxh3Secret = [192]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+const (
+	xxh3Stripe          = 64
+	xxh3StripesPerBlock = (len(xxh3Secret) - xxh3Stripe) / 8
+	xxh3MidSizeMax      = 240
+	xxh3BufSize         = 256
+)
+
+// xxh3 is XXH3 with a 64 or 128-bit output. Inputs of up to 240
+// bytes are hashed by Sum from the buffer; longer ones are consumed
+// a buffer at a time, always keeping the last bytes in the buffer for
+// the final stripe.
+type xxh3 struct {
+	size   int
+	seed   uint64
+	secret [192]byte
+
+	acc      [8]uint64
+	nstripes int
+	buf      [xxh3BufSize]byte
+	nbuf     int
+	n        uint64
+}
+
+func newXXH3(size int, seed uint64) *xxh3 {
+	x := &xxh3{size: size, seed: seed}
+	for i := 0; i < len(x.secret); i += 16 {
+		binary.LittleEndian.PutUint64(x.secret[i:], xxh3Read64(xxh3Secret[:], i)+seed)
+		binary.LittleEndian.PutUint64(x.secret[i+8:], xxh3Read64(xxh3Secret[:], i+8)-seed)
+	}
+	x.Reset()
+	return x
+}
+
+func (x *xxh3) Reset() {
+	x.acc = [8]uint64{
+		xxhPrime32_3, xxhPrime64_1, xxhPrime64_2, xxhPrime64_3,
+		xxhPrime64_4, xxhPrime32_2, xxhPrime64_5, xxhPrime32_1,
+	}
+	x.nstripes = 0
+	x.nbuf = 0
+	x.n = 0
+}
+
+func (x *xxh3) Size() int      { return x.size }
+func (x *xxh3) BlockSize() int { return xxh3Stripe }
+
+func (x *xxh3) Write(p []byte) (int, error) {
+	n := len(p)
+	x.n += uint64(n)
+
+	for len(p) > 0 {
+		if x.nbuf == xxh3BufSize {
+			x.consume(&x.acc, &x.nstripes, x.buf[:])
+			x.nbuf = 0
+		}
+		c := copy(x.buf[x.nbuf:], p)
+		x.nbuf += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// consume accumulates whole stripes, scrambling the accumulators at
+// the end of each block.
+func (x *xxh3) consume(acc *[8]uint64, nstripes *int, p []byte) {
+	for ; len(p) >= xxh3Stripe; p = p[xxh3Stripe:] {
+		xxh3Accumulate(acc, p, x.secret[8**nstripes:])
+		*nstripes++
+		if *nstripes == xxh3StripesPerBlock {
+			xxh3Scramble(acc, x.secret[len(x.secret)-xxh3Stripe:])
+			*nstripes = 0
+		}
+	}
+}
+
+func xxh3Accumulate(acc *[8]uint64, p, secret []byte) {
+	for i := 0; i < 8; i++ {
+		v := xxh3Read64(p, 8*i)
+		k := v ^ xxh3Read64(secret, 8*i)
+		acc[i^1] += v
+		acc[i] += uint64(uint32(k)) * (k >> 32)
+	}
+}
+
+func xxh3Scramble(acc *[8]uint64, secret []byte) {
+	for i := range acc {
+		a := acc[i]
+		a ^= a >> 47
+		a ^= xxh3Read64(secret, 8*i)
+		acc[i] = a * xxhPrime32_1
+	}
+}
+
+// long returns the accumulators of a long input.
+func (x *xxh3) long() [8]uint64 {
+	acc, nstripes := x.acc, x.nstripes
+
+	var last [xxh3Stripe]byte
+	if x.nbuf >= xxh3Stripe {
+		x.consume(&acc, &nstripes, x.buf[:(x.nbuf-1)/xxh3Stripe*xxh3Stripe])
+		copy(last[:], x.buf[x.nbuf-xxh3Stripe:x.nbuf])
+	} else {
+		// the last stripe starts in the previous buffer
+		c := copy(last[:], x.buf[xxh3BufSize-(xxh3Stripe-x.nbuf):])
+		copy(last[c:], x.buf[:x.nbuf])
+	}
+	xxh3Accumulate(&acc, last[:], x.secret[len(x.secret)-xxh3Stripe-7:])
+	return acc
+}
+
+func xxh3MergeAccs(acc *[8]uint64, secret []byte, start uint64) uint64 {
+	h := start
+	for i := 0; i < 4; i++ {
+		h += xxh3Mul128Fold64(acc[2*i]^xxh3Read64(secret, 16*i), acc[2*i+1]^xxh3Read64(secret, 16*i+8))
+	}
+	return xxh3Avalanche(h)
+}
+
+func (x *xxh3) Sum(b []byte) []byte {
+	var d [16]byte
+	if x.size == 8 {
+		binary.BigEndian.PutUint64(d[:], x.Sum64())
+	} else {
+		hi, lo := x.Sum128()
+		binary.BigEndian.PutUint64(d[:], hi)
+		binary.BigEndian.PutUint64(d[8:], lo)
+	}
+	return append(b, d[:x.size]...)
+}
+
+// Sum64 returns the 64-bit hash.
+func (x *xxh3) Sum64() uint64 {
+	if x.n <= xxh3MidSizeMax {
+		p, s, seed := x.buf[:x.n], xxh3Secret[:], x.seed
+		switch {
+		case len(p) <= 16:
+			return xxh3Len0To16(p, s, seed)
+		case len(p) <= 128:
+			return xxh3Len17To128(p, s, seed)
+		default:
+			return xxh3Len129To240(p, s, seed)
+		}
+	}
+
+	acc := x.long()
+	return xxh3MergeAccs(&acc, x.secret[11:], x.n*xxhPrime64_1)
+}
+
+// Sum128 returns the high and low halves of the 128-bit hash.
+func (x *xxh3) Sum128() (uint64, uint64) {
+	if x.n <= xxh3MidSizeMax {
+		p, s, seed := x.buf[:x.n], xxh3Secret[:], x.seed
+		switch {
+		case len(p) <= 16:
+			return xxh3Len0To16_128(p, s, seed)
+		case len(p) <= 128:
+			return xxh3Len17To128_128(p, s, seed)
+		default:
+			return xxh3Len129To240_128(p, s, seed)
+		}
+	}
+
+	acc := x.long()
+	lo := xxh3MergeAccs(&acc, x.secret[11:], x.n*xxhPrime64_1)
+	hi := xxh3MergeAccs(&acc, x.secret[len(x.secret)-xxh3Stripe-11:], ^(x.n * xxhPrime64_2))
+	return hi, lo
+}
+
+func xxh3Read64(p []byte, i int) uint64 { return binary.LittleEndian.Uint64(p[i:]) }
+func xxh3Read32(p []byte, i int) uint32 { return binary.LittleEndian.Uint32(p[i:]) }
+
+func xxh3Mul128Fold64(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi ^ lo
+}
+
+func xxh3Avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= 0x165667919e3779f9
+	h ^= h >> 32
+	return h
+}
+
+func xxh3Rrmxmx(h, n uint64) uint64 {
+	h ^= bits.RotateLeft64(h, 49) ^ bits.RotateLeft64(h, 24)
+	h *= 0x9fb21c651e98df25
+	h ^= (h >> 35) + n
+	h *= 0x9fb21c651e98df25
+	h ^= h >> 28
+	return h
+}
+
+func xxh3Mix16(p []byte, i int, s []byte, j int, seed uint64) uint64 {
+	return xxh3Mul128Fold64(
+		xxh3Read64(p, i)^(xxh3Read64(s, j)+seed),
+		xxh3Read64(p, i+8)^(xxh3Read64(s, j+8)-seed))
+}
+
+func xxh3Len0To16(p, s []byte, seed uint64) uint64 {
+	n := len(p)
+	switch {
+	case n > 8:
+		lo := xxh3Read64(p, 0) ^ ((xxh3Read64(s, 24) ^ xxh3Read64(s, 32)) + seed)
+		hi := xxh3Read64(p, n-8) ^ ((xxh3Read64(s, 40) ^ xxh3Read64(s, 48)) - seed)
+		acc := uint64(n) + bits.ReverseBytes64(lo) + hi + xxh3Mul128Fold64(lo, hi)
+		return xxh3Avalanche(acc)
+	case n >= 4:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		in := uint64(xxh3Read32(p, n-4)) + uint64(xxh3Read32(p, 0))<<32
+		flip := (xxh3Read64(s, 8) ^ xxh3Read64(s, 16)) - seed
+		return xxh3Rrmxmx(in^flip, uint64(n))
+	case n > 0:
+		c := uint32(p[0])<<16 | uint32(p[n>>1])<<24 | uint32(p[n-1]) | uint32(n)<<8
+		flip := uint64(xxh3Read32(s, 0)^xxh3Read32(s, 4)) + seed
+		return xxh64Avalanche(uint64(c) ^ flip)
+	default:
+		return xxh64Avalanche(seed ^ xxh3Read64(s, 56) ^ xxh3Read64(s, 64))
+	}
+}
+
+func xxh3Len17To128(p, s []byte, seed uint64) uint64 {
+	n := len(p)
+	acc := uint64(n) * xxhPrime64_1
+	if n > 32 {
+		if n > 64 {
+			if n > 96 {
+				acc += xxh3Mix16(p, 48, s, 96, seed)
+				acc += xxh3Mix16(p, n-64, s, 112, seed)
+			}
+			acc += xxh3Mix16(p, 32, s, 64, seed)
+			acc += xxh3Mix16(p, n-48, s, 80, seed)
+		}
+		acc += xxh3Mix16(p, 16, s, 32, seed)
+		acc += xxh3Mix16(p, n-32, s, 48, seed)
+	}
+	acc += xxh3Mix16(p, 0, s, 0, seed)
+	acc += xxh3Mix16(p, n-16, s, 16, seed)
+	return xxh3Avalanche(acc)
+}
+
+func xxh3Len129To240(p, s []byte, seed uint64) uint64 {
+	n := len(p)
+	acc := uint64(n) * xxhPrime64_1
+	for i := 0; i < 8; i++ {
+		acc += xxh3Mix16(p, 16*i, s, 16*i, seed)
+	}
+	acc = xxh3Avalanche(acc)
+	for i := 8; i < n/16; i++ {
+		acc += xxh3Mix16(p, 16*i, s, 16*(i-8)+3, seed)
+	}
+	acc += xxh3Mix16(p, n-16, s, 136-17, seed)
+	return xxh3Avalanche(acc)
+}
+
+func xxh3Len0To16_128(p, s []byte, seed uint64) (uint64, uint64) {
+	n := len(p)
+	switch {
+	case n > 8:
+		flipl := (xxh3Read64(s, 32) ^ xxh3Read64(s, 40)) - seed
+		fliph := (xxh3Read64(s, 48) ^ xxh3Read64(s, 56)) + seed
+		inlo := xxh3Read64(p, 0)
+		inhi := xxh3Read64(p, n-8)
+
+		mhi, mlo := bits.Mul64(inlo^inhi^flipl, xxhPrime64_1)
+		mlo += uint64(n-1) << 54
+		inhi ^= fliph
+		mhi += inhi + uint64(uint32(inhi))*(xxhPrime32_2-1)
+		mlo ^= bits.ReverseBytes64(mhi)
+
+		hi, lo := bits.Mul64(mlo, xxhPrime64_2)
+		hi += mhi * xxhPrime64_2
+		return xxh3Avalanche(hi), xxh3Avalanche(lo)
+	case n >= 4:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		in := uint64(xxh3Read32(p, 0)) + uint64(xxh3Read32(p, n-4))<<32
+		flip := (xxh3Read64(s, 16) ^ xxh3Read64(s, 24)) + seed
+
+		hi, lo := bits.Mul64(in^flip, xxhPrime64_1+uint64(n)<<2)
+		hi += lo << 1
+		lo ^= hi >> 3
+		lo ^= lo >> 35
+		lo *= 0x9fb21c651e98df25
+		lo ^= lo >> 28
+		return xxh3Avalanche(hi), lo
+	case n > 0:
+		cl := uint32(p[0])<<16 | uint32(p[n>>1])<<24 | uint32(p[n-1]) | uint32(n)<<8
+		ch := bits.RotateLeft32(bits.ReverseBytes32(cl), 13)
+		flipl := uint64(xxh3Read32(s, 0)^xxh3Read32(s, 4)) + seed
+		fliph := uint64(xxh3Read32(s, 8)^xxh3Read32(s, 12)) - seed
+		return xxh64Avalanche(uint64(ch) ^ fliph), xxh64Avalanche(uint64(cl) ^ flipl)
+	default:
+		return xxh64Avalanche(seed ^ xxh3Read64(s, 80) ^ xxh3Read64(s, 88)),
+			xxh64Avalanche(seed ^ xxh3Read64(s, 64) ^ xxh3Read64(s, 72))
+	}
+}
+
+func xxh3Mix32(lo, hi *uint64, p []byte, i1, i2 int, s []byte, j int, seed uint64) {
+	*lo += xxh3Mix16(p, i1, s, j, seed)
+	*lo ^= xxh3Read64(p, i2) + xxh3Read64(p, i2+8)
+	*hi += xxh3Mix16(p, i2, s, j+16, seed)
+	*hi ^= xxh3Read64(p, i1) + xxh3Read64(p, i1+8)
+}
+
+func xxh3Finish128(lo, hi, n, seed uint64) (uint64, uint64) {
+	h := lo*xxhPrime64_1 + hi*xxhPrime64_4 + (n-seed)*xxhPrime64_2
+	return -xxh3Avalanche(h), xxh3Avalanche(lo + hi)
+}
+
+func xxh3Len17To128_128(p, s []byte, seed uint64) (uint64, uint64) {
+	n := len(p)
+	lo, hi := uint64(n)*xxhPrime64_1, uint64(0)
+	if n > 32 {
+		if n > 64 {
+			if n > 96 {
+				xxh3Mix32(&lo, &hi, p, 48, n-64, s, 96, seed)
+			}
+			xxh3Mix32(&lo, &hi, p, 32, n-48, s, 64, seed)
+		}
+		xxh3Mix32(&lo, &hi, p, 16, n-32, s, 32, seed)
+	}
+	xxh3Mix32(&lo, &hi, p, 0, n-16, s, 0, seed)
+	return xxh3Finish128(lo, hi, uint64(n), seed)
+}
+
+func xxh3Len129To240_128(p, s []byte, seed uint64) (uint64, uint64) {
+	n := len(p)
+	lo, hi := uint64(n)*xxhPrime64_1, uint64(0)
+	for i := 0; i < 4; i++ {
+		xxh3Mix32(&lo, &hi, p, 32*i, 32*i+16, s, 32*i, seed)
+	}
+	lo, hi = xxh3Avalanche(lo), xxh3Avalanche(hi)
+	for i := 4; i < n/32; i++ {
+		xxh3Mix32(&lo, &hi, p, 32*i, 32*i+16, s, 3+32*(i-4), seed)
+	}
+	xxh3Mix32(&lo, &hi, p, n-16, n-32, s, 136-17-16, -seed)
+	return xxh3Finish128(lo, hi, uint64(n), seed)
+}